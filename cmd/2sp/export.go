@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+	"github.com/six78/2-story-points-cli/pkg/report"
+	"github.com/six78/2-story-points-cli/pkg/storage"
+	"os"
+)
+
+const exportCommand = "export"
+
+// runExport prints the report of a room from the local storage, without joining the room.
+// Usage: 2sp export [-format markdown|csv|json] [-output file] <roomID>
+func runExport(args []string) int {
+	flags := flag.NewFlagSet(exportCommand, flag.ContinueOnError)
+	format := flags.String("format", string(report.FormatMarkdown), "Report format: markdown, csv or json")
+	output := flags.String("output", "", "Output file, stdout by default")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: 2sp export [-format markdown|csv|json] [-output file] <roomID>")
+		return 2
+	}
+
+	err := exportRoom(flags.Arg(0), *format, *output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func exportRoom(input string, formatName string, output string) error {
+	format, err := report.ParseFormat(formatName)
+	if err != nil {
+		return err
+	}
+
+	room, err := protocol.ParseRoomID(input)
+	if err != nil {
+		return err
+	}
+
+	localStorage := storage.NewLocalStorage("")
+	err = localStorage.Initialize()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize storage")
+	}
+
+	state, err := localStorage.LoadRoomState(room.ToRoomID())
+	if err != nil {
+		return errors.Wrap(err, "failed to load room state")
+	}
+
+	r, err := report.Build(state)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		return report.Write(os.Stdout, r, format)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return errors.Wrap(err, "failed to create report file")
+	}
+
+	err = report.Write(file, r, format)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return errors.Wrap(file.Close(), "failed to save report file")
+}
@@ -2,12 +2,16 @@ package main
 
 import (
 	"context"
+	"flag"
 	"github.com/jonboulle/clockwork"
 	"github.com/six78/2-story-points-cli/internal/config"
 	"github.com/six78/2-story-points-cli/internal/transport"
 	"github.com/six78/2-story-points-cli/internal/view"
 	"github.com/six78/2-story-points-cli/pkg/game"
+	"github.com/six78/2-story-points-cli/pkg/githubsync"
+	"github.com/six78/2-story-points-cli/pkg/issueprovider"
 	"github.com/six78/2-story-points-cli/pkg/storage"
+	"go.uber.org/zap"
 	"os"
 )
 
@@ -15,6 +19,10 @@ func main() {
 	config.ParseArguments()
 	config.SetupLogger()
 
+	if args := flag.Args(); len(args) > 0 && args[0] == exportCommand {
+		os.Exit(runExport(args[1:]))
+	}
+
 	ctx, quit := context.WithCancel(context.Background())
 	defer quit()
 
@@ -27,19 +35,26 @@ func main() {
 		game.WithStorage(createStorage()),
 		game.WithLogger(config.Logger.Named("game")),
 		game.WithPlayerName(config.PlayerName()),
+		game.WithSpectator(config.Spectator()),
 		game.WithOnlineMessagePeriod(config.OnlineMessagePeriod),
 		game.WithStateMessagePeriod(config.StateMessagePeriod),
+		game.WithDealerTimeout(config.DealerTimeout()),
 		game.WithEnableSymmetricEncryption(config.EnableSymmetricEncryption),
 		game.WithClock(clockwork.NewRealClock()),
 	}
 
+	providers := createIssueProviders()
+	options = append(options, game.WithIssueSync(providers))
+
 	game := game.NewGame(options)
 	if game == nil {
 		config.Logger.Fatal("could not create game")
 	}
-	defer game.Stop()
 
-	code := view.Run(game, waku)
+	code := view.Run(game, waku, providers)
+
+	// os.Exit skips deferred calls, stop explicitly to let issue results sync
+	game.Stop()
 	os.Exit(code)
 }
 
@@ -49,3 +64,55 @@ func createStorage() storage.Service {
 	}
 	return storage.NewLocalStorage("")
 }
+
+// createIssueProviders creates providers for configured issue trackers.
+// GitHub and GitLab public issues are available without a token.
+func createIssueProviders() issueprovider.Providers {
+	var providers issueprovider.Providers
+	add := func(provider issueprovider.Provider, err error) {
+		if err != nil {
+			config.Logger.Error("failed to create issue provider", zap.Error(err))
+			return
+		}
+		providers = append(providers, provider)
+	}
+
+	add(issueprovider.NewGitHub(githubsync.Config{
+		Token:         config.GitHubToken(),
+		LabelPrefix:   config.GitHubLabel(),
+		ProjectNumber: config.GitHubProject(),
+		Field:         config.GitHubField(),
+		Comment:       config.GitHubComment(),
+		BaseURL:       config.GitHubURL(),
+	}))
+
+	add(issueprovider.NewGitLab(issueprovider.GitLabConfig{
+		BaseURL: config.GitLabURL(),
+		Token:   config.GitLabToken(),
+		Weight:  config.GitLabWeight(),
+	}))
+
+	if config.JiraURL() != "" {
+		add(issueprovider.NewJira(issueprovider.JiraConfig{
+			BaseURL:       config.JiraURL(),
+			User:          config.JiraUser(),
+			Token:         config.JiraToken(),
+			EstimateField: config.JiraField(),
+		}))
+	}
+
+	if config.LinearToken() != "" {
+		add(issueprovider.NewLinear(issueprovider.LinearConfig{
+			BaseURL:  config.LinearURL(),
+			Token:    config.LinearToken(),
+			Estimate: config.LinearEstimate(),
+		}))
+	}
+
+	// Generic provider matches any link, so it goes last
+	if config.WebUnfurl() {
+		providers = append(providers, issueprovider.NewGeneric())
+	}
+
+	return providers
+}
@@ -28,12 +28,32 @@ var fleet string
 var nameserver string
 var playerName string
 var initialAction string
+var importFile string
 var debug bool
 var anonymous bool
+var spectator bool
 var wakuStaticNodes StaticWakuNodes
 var wakuLightMode bool
 var wakuDiscV5 bool
 var wakuDnsDiscovery bool
+var dealerTimeout time.Duration
+var githubToken string
+var githubURL string
+var githubLabel string
+var githubProject int
+var githubField string
+var githubComment bool
+var gitlabURL string
+var gitlabToken string
+var gitlabWeight bool
+var jiraURL string
+var jiraUser string
+var jiraToken string
+var jiraField string
+var linearURL string
+var linearToken string
+var linearEstimate bool
+var webUnfurl bool
 
 var Logger *zap.Logger
 var LogFilePath string
@@ -99,14 +119,38 @@ func ParseArguments() {
 	flag.StringVar(&playerName, "name", "", "Player name")
 	flag.BoolVar(&debug, "debug", false, "Show debug info")
 	flag.BoolVar(&anonymous, "anonymous", false, "Anonymous mode")
+	flag.BoolVar(&spectator, "spectator", false, "Join rooms as a spectator, without voting")
 	flag.StringVar(&fleet, "waku.fleet", "shards.test", "Waku fleet name")
 	flag.StringVar(&nameserver, "waku.nameserver", "", "Waku nameserver")
 	flag.Var(&wakuStaticNodes, "waku.staticnode", "Waku static node multiaddress")
 	flag.BoolVar(&wakuLightMode, "waku.lightmode", false, "Waku lightpush/filter mode")
 	flag.BoolVar(&wakuDiscV5, "waku.discv5", true, "Enable DiscV5 discovery")
 	flag.BoolVar(&wakuDnsDiscovery, "waku.dnsdiscovery", true, "Enable DNS discovery")
+	flag.StringVar(&importFile, "import", "", "Backlog file (CSV, JSON or a plain list) to import when the room is joined as a dealer")
+	flag.DurationVar(&dealerTimeout, "dealer.timeout", 90*time.Second, "Time without dealer state messages before another player takes over")
+	flag.StringVar(&githubToken, "github.token", "", "GitHub token, by default taken from GITHUB_TOKEN, GH_TOKEN, the config file or GitHub CLI")
+	flag.StringVar(&githubURL, "github.url", "", "GitHub Enterprise API URL, e.g. https://github.example.com/api/v3/")
+	flag.StringVar(&githubLabel, "github.label", "", "Set the estimate as a label with this prefix, e.g. 'sp:'")
+	flag.IntVar(&githubProject, "github.project", 0, "Projects v2 board number to write the estimate to")
+	flag.StringVar(&githubField, "github.field", "", "Number field of the Projects v2 board to write the estimate to")
+	flag.BoolVar(&githubComment, "github.comment", false, "Post the vote distribution as an issue comment")
+	flag.StringVar(&gitlabURL, "gitlab.url", "", "GitLab instance URL, https://gitlab.com by default")
+	flag.StringVar(&gitlabToken, "gitlab.token", "", "GitLab token, GITLAB_TOKEN by default")
+	flag.BoolVar(&gitlabWeight, "gitlab.weight", false, "Write estimates to the GitLab issue weight")
+	flag.StringVar(&jiraURL, "jira.url", "", "Jira site URL, e.g. https://example.atlassian.net")
+	flag.StringVar(&jiraUser, "jira.user", "", "Jira Cloud account email, personal access token is used when empty")
+	flag.StringVar(&jiraToken, "jira.token", "", "Jira API token, JIRA_API_TOKEN by default")
+	flag.StringVar(&jiraField, "jira.field", "", "Jira field to write estimates to, e.g. customfield_10016")
+	flag.StringVar(&linearURL, "linear.url", "", "Linear API URL, https://api.linear.app by default")
+	flag.StringVar(&linearToken, "linear.token", "", "Linear API key, LINEAR_API_KEY by default")
+	flag.BoolVar(&linearEstimate, "linear.estimate", false, "Write estimates to the Linear issue estimate")
+	flag.BoolVar(&webUnfurl, "web.unfurl", false, "Fetch titles of links to any public website")
 	flag.Parse()
 
+	file := readConfigFile()
+	resolveGitHub(file)
+	resolveTrackers(file)
+
 	initialAction = strings.Join(flag.Args(), " ")
 }
 
@@ -130,6 +174,78 @@ func InitialAction() string {
 	return initialAction
 }
 
+func ImportFile() string {
+	return importFile
+}
+
+func GitHubToken() string {
+	return githubToken
+}
+
+func GitHubURL() string {
+	return githubURL
+}
+
+func GitHubLabel() string {
+	return githubLabel
+}
+
+func GitHubProject() int {
+	return githubProject
+}
+
+func GitHubField() string {
+	return githubField
+}
+
+func GitHubComment() bool {
+	return githubComment
+}
+
+func GitLabURL() string {
+	return gitlabURL
+}
+
+func GitLabToken() string {
+	return gitlabToken
+}
+
+func GitLabWeight() bool {
+	return gitlabWeight
+}
+
+func JiraURL() string {
+	return jiraURL
+}
+
+func JiraUser() string {
+	return jiraUser
+}
+
+func JiraToken() string {
+	return jiraToken
+}
+
+func JiraField() string {
+	return jiraField
+}
+
+func LinearURL() string {
+	return linearURL
+}
+
+func LinearToken() string {
+	return linearToken
+}
+
+func LinearEstimate() bool {
+	return linearEstimate
+}
+
+func WebUnfurl() bool {
+	return webUnfurl
+}
+
 func Debug() bool {
 	return debug
 }
@@ -138,6 +254,10 @@ func Anonymous() bool {
 	return anonymous
 }
 
+func Spectator() bool {
+	return spectator
+}
+
 func WakuStaticNodes() []string {
 	return wakuStaticNodes
 }
@@ -153,3 +273,7 @@ func WakuDiscV5() bool {
 func WakuDnsDiscovery() bool {
 	return wakuDnsDiscovery
 }
+
+func DealerTimeout() time.Duration {
+	return dealerTimeout
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/shibukawa/configdir"
+)
+
+const configFileName = "config.json"
+
+// trackerConfig holds the issue tracker access, User is only used by Jira
+type trackerConfig struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+	User  string `json:"user"`
+}
+
+// fileConfig is the optional config file in the application config folder.
+// Flags and env variables have priority over the file.
+type fileConfig struct {
+	GitHub trackerConfig `json:"github"`
+	GitLab trackerConfig `json:"gitlab"`
+	Jira   trackerConfig `json:"jira"`
+	Linear trackerConfig `json:"linear"`
+}
+
+func readConfigFile() fileConfig {
+	var file fileConfig
+
+	folders := configdir.New(VendorName, ApplicationName).QueryFolders(configdir.Global)
+	if len(folders) == 0 {
+		return file
+	}
+
+	data, err := os.ReadFile(filepath.Join(folders[0].Path, configFileName))
+	if err != nil {
+		return file
+	}
+
+	// Broken config file shouldn't prevent the app from starting
+	_ = json.Unmarshal(data, &file)
+	return file
+}
+
+// resolveTrackers fills issue trackers settings that were not passed with flags
+func resolveTrackers(file fileConfig) {
+	gitlabURL = firstNonEmpty(gitlabURL, file.GitLab.URL)
+	gitlabToken = firstNonEmpty(gitlabToken, os.Getenv("GITLAB_TOKEN"), file.GitLab.Token)
+	jiraURL = firstNonEmpty(jiraURL, file.Jira.URL)
+	jiraUser = firstNonEmpty(jiraUser, file.Jira.User)
+	jiraToken = firstNonEmpty(jiraToken, os.Getenv("JIRA_API_TOKEN"), file.Jira.Token)
+	linearURL = firstNonEmpty(linearURL, file.Linear.URL)
+	linearToken = firstNonEmpty(linearToken, os.Getenv("LINEAR_API_KEY"), file.Linear.Token)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTrackers(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "gitlab_env")
+	t.Setenv("JIRA_API_TOKEN", "")
+	t.Setenv("LINEAR_API_KEY", "")
+
+	gitlabURL, gitlabToken = "", ""
+	jiraURL, jiraUser, jiraToken = "https://flag.atlassian.net", "", ""
+	linearURL, linearToken = "", "linear_flag"
+	t.Cleanup(func() {
+		gitlabURL, gitlabToken = "", ""
+		jiraURL, jiraUser, jiraToken = "", "", ""
+		linearURL, linearToken = "", ""
+	})
+
+	resolveTrackers(fileConfig{
+		GitLab: trackerConfig{URL: "https://gitlab.example.com", Token: "gitlab_file"},
+		Jira:   trackerConfig{URL: "https://file.atlassian.net", User: "user@example.com", Token: "jira_file"},
+		Linear: trackerConfig{Token: "linear_file"},
+	})
+
+	// Flags, then env variables, then the config file
+	require.Equal(t, "https://gitlab.example.com", gitlabURL)
+	require.Equal(t, "gitlab_env", gitlabToken)
+	require.Equal(t, "https://flag.atlassian.net", jiraURL)
+	require.Equal(t, "user@example.com", jiraUser)
+	require.Equal(t, "jira_file", jiraToken)
+	require.Equal(t, "", linearURL)
+	require.Equal(t, "linear_flag", linearToken)
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/six78/2-story-points-cli/internal/githubapi"
+)
+
+// resolveGitHub fills GitHub settings that were not passed with flags.
+// The token is taken from GITHUB_TOKEN or GH_TOKEN env variables,
+// then from the config file and then from the GitHub CLI hosts file.
+func resolveGitHub(file fileConfig) {
+	githubURL = firstNonEmpty(githubURL, file.GitHub.URL)
+	githubToken = firstNonEmpty(githubToken, os.Getenv("GITHUB_TOKEN"), os.Getenv("GH_TOKEN"), file.GitHub.Token)
+	if githubToken == "" {
+		githubToken = readGhToken(githubapi.WebHost(githubURL))
+	}
+}
+
+// readGhToken reads the token saved by GitHub CLI, if it's not stored in the system keyring
+func readGhToken(host string) string {
+	data, err := os.ReadFile(filepath.Join(ghConfigDir(), "hosts.yml"))
+	if err != nil {
+		return ""
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	err = yaml.Unmarshal(data, &hosts)
+	if err != nil {
+		return ""
+	}
+
+	return hosts[host].OAuthToken
+}
+
+// ghConfigDir follows the GitHub CLI config folder lookup
+func ghConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if dir := os.Getenv("AppData"); runtime.GOOS == "windows" && dir != "" {
+		return filepath.Join(dir, "GitHub CLI")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
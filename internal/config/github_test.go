@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGhToken(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", dir)
+
+	require.Empty(t, readGhToken("github.com"))
+
+	hosts := `github.com:
+    user: player
+    oauth_token: gho_token
+    git_protocol: https
+github.example.com:
+    user: player
+`
+	err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0600)
+	require.NoError(t, err)
+
+	require.Equal(t, "gho_token", readGhToken("github.com"))
+	// Token stored in the keyring
+	require.Empty(t, readGhToken("github.example.com"))
+	require.Empty(t, readGhToken("unknown.com"))
+}
+
+func TestResolveGitHubToken(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "gh_token")
+	defer func() {
+		githubToken = ""
+	}()
+
+	// Flag has priority
+	githubToken = "flag_token"
+	resolveGitHub(fileConfig{})
+	require.Equal(t, "flag_token", githubToken)
+
+	githubToken = ""
+	resolveGitHub(fileConfig{})
+	require.Equal(t, "gh_token", githubToken)
+
+	t.Setenv("GITHUB_TOKEN", "github_token")
+	githubToken = ""
+	resolveGitHub(fileConfig{})
+	require.Equal(t, "github_token", githubToken)
+}
@@ -0,0 +1,91 @@
+package githubapi
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/pkg/errors"
+)
+
+const defaultHost = "github.com"
+
+// NewClient creates a GitHub client. Anonymous access is used without a token.
+// With empty baseURL github.com is used, otherwise it's treated as GitHub Enterprise API URL.
+func NewClient(token string, baseURL string) (*github.Client, error) {
+	client := github.NewClient(nil)
+	if token != "" {
+		client = client.WithAuthToken(token)
+	}
+	if baseURL == "" {
+		return client, nil
+	}
+	client, err := client.WithEnterpriseURLs(baseURL, baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid github api url")
+	}
+	return client, nil
+}
+
+// WebHost returns the host of issue links for given API URL
+func WebHost(baseURL string) string {
+	if baseURL == "" {
+		return defaultHost
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Hostname() == "" {
+		return defaultHost
+	}
+	host := strings.TrimPrefix(u.Hostname(), "api.")
+	if u.Port() != "" {
+		host += ":" + u.Port()
+	}
+	return host
+}
+
+// IssueRef points to a GitHub issue
+type IssueRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// ParseIssueURL returns false for links that are not issues on given host
+func ParseIssueURL(input string, host string) (*IssueRef, bool) {
+	u, err := url.Parse(input)
+	if err != nil || u.Host != host {
+		return nil, false
+	}
+
+	path := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(path) != 4 || path[2] != "issues" {
+		return nil, false
+	}
+
+	number, err := strconv.Atoi(path[3])
+	if err != nil {
+		return nil, false
+	}
+
+	return &IssueRef{
+		Owner:  path[0],
+		Repo:   path[1],
+		Number: number,
+	}, true
+}
+
+// RateLimitReset returns the time when the rate limit is reset,
+// if the request failed because of the rate limit
+func RateLimitReset(err error) (time.Time, bool) {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr.Rate.Reset.Time, true
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return time.Now().Add(abuseErr.GetRetryAfter()), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,86 @@
+package githubapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebHost(t *testing.T) {
+	require.Equal(t, "github.com", WebHost(""))
+	require.Equal(t, "github.com", WebHost("https://api.github.com/"))
+	require.Equal(t, "github.example.com", WebHost("https://github.example.com/api/v3/"))
+	require.Equal(t, "127.0.0.1:8080", WebHost("http://127.0.0.1:8080"))
+}
+
+func TestParseIssueURL(t *testing.T) {
+	ref, ok := ParseIssueURL("https://github.com/six78/2-story-points-cli/issues/42", "github.com")
+	require.True(t, ok)
+	require.Equal(t, IssueRef{Owner: "six78", Repo: "2-story-points-cli", Number: 42}, *ref)
+
+	ref, ok = ParseIssueURL("https://github.example.com/six78/2-story-points-cli/issues/42", "github.example.com")
+	require.True(t, ok)
+	require.Equal(t, 42, ref.Number)
+
+	for _, input := range []string{
+		"Just a title",
+		"https://github.example.com/six78/2-story-points-cli/issues/42",
+		"https://github.com/six78/2-story-points-cli/pull/42",
+		"https://github.com/six78/2-story-points-cli/issues/abc",
+	} {
+		_, ok = ParseIssueURL(input, "github.com")
+		require.False(t, ok, input)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v3/repos/six78/2-story-points-cli/issues/42", r.URL.Path)
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"number": 42}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("token", server.URL)
+	require.NoError(t, err)
+
+	issue, _, err := client.Issues.Get(context.Background(), "six78", "2-story-points-cli", 42)
+	require.NoError(t, err)
+	require.Equal(t, 42, issue.GetNumber())
+	require.Equal(t, "Bearer token", authorization)
+
+	client, err = NewClient("", "")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.github.com/", client.BaseURL.String())
+}
+
+func TestRateLimitReset(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("", server.URL)
+	require.NoError(t, err)
+
+	_, _, err = client.Issues.Get(context.Background(), "six78", "2-story-points-cli", 42)
+	require.Error(t, err)
+
+	received, ok := RateLimitReset(err)
+	require.True(t, ok)
+	require.True(t, reset.Equal(received))
+
+	_, ok = RateLimitReset(context.DeadlineExceeded)
+	require.False(t, ok)
+}
@@ -1,7 +1,6 @@
 package matchers
 
 import (
-	"encoding/json"
 	"github.com/six78/2-story-points-cli/internal/config"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 	"go.uber.org/zap"
@@ -30,7 +29,7 @@ func (m *OnlineMatcher) Matches(x interface{}) bool {
 	}
 
 	var onlineMessage protocol.PlayerOnlineMessage
-	err := json.Unmarshal(m.payload, &onlineMessage)
+	_, err := protocol.UnmarshalSignedMessage(m.payload, &onlineMessage)
 	if err != nil {
 		return false
 	}
@@ -1,7 +1,6 @@
 package matchers
 
 import (
-	"encoding/json"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 	"testing"
 )
@@ -32,7 +31,7 @@ func (m *StateMatcher) Matches(x interface{}) bool {
 	}
 
 	var stateMessage protocol.GameStateMessage
-	err := json.Unmarshal(m.payload, &stateMessage)
+	_, err := protocol.UnmarshalSignedMessage(m.payload, &stateMessage)
 	if err != nil {
 		return false
 	}
@@ -1,6 +1,10 @@
 package transport
 
-import "github.com/six78/2-story-points-cli/pkg/protocol"
+import (
+	"crypto/ecdsa"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
 
 //go:generate mockgen -source=service.go -destination=mock/service.go
 
@@ -11,7 +15,7 @@ type Service interface {
 	SubscribeToMessages(room *protocol.Room) (*MessagesSubscription, error)
 	PublishUnencryptedMessage(room *protocol.Room, payload []byte) error
 	PublishPublicMessage(room *protocol.Room, payload []byte) error
-	PublishPrivateMessage(room *protocol.Room, payload []byte) error
+	PublishPrivateMessage(room *protocol.Room, payload []byte, publicKey *ecdsa.PublicKey) error
 
 	ConnectionStatus() ConnectionStatus
 	SubscribeToConnectionStatus() ConnectionStatusSubscription
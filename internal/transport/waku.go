@@ -2,6 +2,7 @@ package transport
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"net"
 	"strings"
@@ -294,9 +295,20 @@ func (n *Node) PublishPublicMessage(room *pp.Room, payload []byte) error {
 	return n.publishWakuMessage(message)
 }
 
-func (n *Node) PublishPrivateMessage(room *pp.Room, payload []byte) error {
-	n.logger.Error("PublishPrivateMessage not implemented")
-	return errors.New("PublishPrivateMessage not implemented")
+// PublishPrivateMessage publishes a payload that can only be read by the owner of given public key.
+// The encrypted payload is additionally wrapped with room symmetric encryption,
+// so that it's not even visible to anyone outside the room.
+func (n *Node) PublishPrivateMessage(room *pp.Room, payload []byte, publicKey *ecdsa.PublicKey) error {
+	if publicKey == nil {
+		return errors.New("no public key provided")
+	}
+
+	privatePayload, err := pp.EncryptPrivateMessage(payload, publicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt private message")
+	}
+
+	return n.PublishPublicMessage(room, privatePayload)
 }
 
 func (n *Node) buildWakuMessage(room *pp.Room, payload []byte) (*pb.WakuMessage, error) {
@@ -7,45 +7,86 @@ import (
 	"github.com/six78/2-story-points-cli/internal/view/commands"
 	"github.com/six78/2-story-points-cli/internal/view/messages"
 	"github.com/six78/2-story-points-cli/internal/view/states"
+	"github.com/six78/2-story-points-cli/pkg/backlog"
 	"github.com/six78/2-story-points-cli/pkg/game"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
+	"github.com/six78/2-story-points-cli/pkg/report"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Action string
 
 const (
-	Rename Action = "rename"
-	New    Action = "new"
-	Join   Action = "join"
-	Exit   Action = "exit"
-	Vote   Action = "vote"
-	Unvote Action = "unvote"
-	Deal   Action = "deal"
-	Add    Action = "add"
-	Reveal Action = "reveal"
-	Finish Action = "finish"
-	Deck   Action = "deck"
-	Select Action = "select"
+	Rename    Action = "rename"
+	New       Action = "new"
+	Join      Action = "join"
+	Exit      Action = "exit"
+	Vote      Action = "vote"
+	Unvote    Action = "unvote"
+	Deal      Action = "deal"
+	Add       Action = "add"
+	Reveal    Action = "reveal"
+	Finish    Action = "finish"
+	Deck      Action = "deck"
+	Select    Action = "select"
+	Handover  Action = "handover"
+	Set       Action = "set"
+	Spectator Action = "spectator"
+	Revote    Action = "revote"
+	Kick      Action = "kick"
+	Ban       Action = "ban"
+	RotateKey Action = "rotate-key"
+	Approve   Action = "approve"
+	Reject    Action = "reject"
+	Remove    Action = "remove"
+	Move      Action = "move"
+	Retitle   Action = "rename-issue"
+	Reset     Action = "reset"
+	Defer     Action = "defer"
+	Skip      Action = "skip"
+	Edit      Action = "edit"
+	Export    Action = "export"
+	Import    Action = "import"
 )
 
 type actionFunc func(m *model, args []string) tea.Cmd
 
 var actions = map[Action]actionFunc{
-	Rename: runRenameAction,
-	Vote:   runVoteAction,
-	Unvote: runUnvoteAction,
-	Deal:   runDealAction,
-	Add:    runAddAction,
-	New:    runNewAction,
-	Join:   runJoinAction,
-	Exit:   runExitAction,
-	Reveal: runRevealAction,
-	Finish: runFinishAction,
-	Deck:   runDeckAction,
-	Select: runSelectAction,
+	Rename:    runRenameAction,
+	Vote:      runVoteAction,
+	Unvote:    runUnvoteAction,
+	Deal:      runDealAction,
+	Add:       runAddAction,
+	New:       runNewAction,
+	Join:      runJoinAction,
+	Exit:      runExitAction,
+	Reveal:    runRevealAction,
+	Finish:    runFinishAction,
+	Deck:      runDeckAction,
+	Select:    runSelectAction,
+	Handover:  runHandoverAction,
+	Set:       runSetAction,
+	Spectator: runSpectatorAction,
+	Revote:    runRevoteAction,
+	Kick:      runKickAction,
+	Ban:       runBanAction,
+	RotateKey: runRotateKeyAction,
+	Approve:   runApproveAction,
+	Reject:    runRejectAction,
+	Remove:    runRemoveAction,
+	Move:      runMoveAction,
+	Retitle:   runRetitleAction,
+	Reset:     runResetAction,
+	Defer:     runDeferAction,
+	Skip:      runSkipAction,
+	Edit:      runEditAction,
+	Export:    runExportAction,
+	Import:    runImportAction,
 }
 
 func processPlayerNameInput(m *model, playerName string) tea.Cmd {
@@ -98,15 +139,40 @@ func runUnvoteAction(m *model, args []string) tea.Cmd {
 	}
 }
 
+// parseTimer extracts "--timer <duration>" from the arguments
+func parseTimer(args []string) (*time.Duration, []string, error) {
+	index := slices.Index(args, "--timer")
+	if index < 0 {
+		return nil, args, nil
+	}
+	if index+1 >= len(args) {
+		return nil, nil, errors.New("no timer duration provided")
+	}
+	timer, err := time.ParseDuration(args[index+1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid timer: '%s', expected a duration, e.g. 2m", args[index+1])
+	}
+	rest := append(slices.Clone(args[:index]), args[index+2:]...)
+	return &timer, rest, nil
+}
+
 func runDealAction(m *model, args []string) tea.Cmd {
 	return func() tea.Msg {
+		timer, args, err := parseTimer(args)
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
 		if len(args) == 0 {
 			err := errors.New("empty deal")
 			return messages.NewErrorMessage(err)
 		}
 		// TODO: Find a better way of restoring empty spaces between args
 		issue := strings.Join(args, " ")
-		_, err := m.game.Deal(issue)
+		if timer != nil {
+			_, err = m.game.DealWithTimer(issue, *timer)
+		} else {
+			_, err = m.game.Deal(issue)
+		}
 		return messages.NewErrorMessage(err)
 	}
 }
@@ -122,7 +188,18 @@ func runAddAction(m *model, args []string) tea.Cmd {
 }
 
 func runNewAction(m *model, args []string) tea.Cmd {
-	return commands.CreateNewRoom(m.game)
+	if len(args) == 0 {
+		return commands.CreateNewRoom(m.game, nil)
+	}
+
+	deck, err := parseDeck(m, args)
+	if err != nil {
+		return func() tea.Msg {
+			return messages.NewErrorMessage(err)
+		}
+	}
+
+	return commands.CreateNewRoom(m.game, deck)
 }
 
 func runJoinAction(m *model, args []string) tea.Cmd {
@@ -153,6 +230,13 @@ func runRevealAction(m *model, args []string) tea.Cmd {
 	}
 }
 
+func runRevoteAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.game.Revote()
+		return messages.NewErrorMessage(err)
+	}
+}
+
 func runFinishAction(m *model, args []string) tea.Cmd {
 	return func() tea.Msg {
 		if len(args) == 0 {
@@ -172,39 +256,59 @@ func runFinishAction(m *model, args []string) tea.Cmd {
 	}
 }
 
-func parseDeck(args []string) (protocol.Deck, error) {
+const (
+	deckSave   = "save"
+	deckList   = "list"
+	deckDelete = "delete"
+)
+
+// availableDecks returns names of built-in decks, followed by names of saved decks
+func availableDecks(m *model) []string {
+	names := game.AvailableDecks()
+	saved, err := m.game.SavedDecks()
+	if err != nil {
+		return names
+	}
+	savedNames := maps.Keys(saved)
+	slices.Sort(savedNames)
+	return append(names, savedNames...)
+}
+
+func parseDeck(m *model, args []string) (protocol.Deck, error) {
 	if len(args) == 0 {
-		return nil, errors.New("deck can't be empty")
+		return nil, fmt.Errorf("deck can't be empty, available decks: %s",
+			strings.Join(availableDecks(m), ", "))
 	}
 
 	if len(args) == 1 {
 		// attempt to parse deck by name
 		deckName := strings.ToLower(args[0])
-		deck, ok := game.GetDeck(deckName)
+		deck, ok := m.game.FindDeck(deckName)
 		if !ok {
 			return nil, fmt.Errorf("unknown deck: '%s', available decks: %s",
-				args[0], strings.Join(game.AvailableDecks(), ", "))
+				args[0], strings.Join(availableDecks(m), ", "))
 		}
 		return deck, nil
 	}
 
-	deck := protocol.Deck{}
-	cards := map[string]struct{}{}
+	deck := game.CreateDeck(args)
+	return deck, deck.Validate()
+}
 
-	for _, card := range args {
-		if _, ok := cards[card]; ok {
-			return nil, fmt.Errorf("duplicate card: '%s'", card)
+func runDeckAction(m *model, args []string) tea.Cmd {
+	if len(args) > 0 {
+		switch args[0] {
+		case deckSave:
+			return runDeckSaveAction(m, args[1:])
+		case deckList:
+			return runDeckListAction(m)
+		case deckDelete:
+			return runDeckDeleteAction(m, args[1:])
 		}
-		cards[card] = struct{}{}
-		deck = append(deck, protocol.VoteValue(card))
 	}
 
-	return deck, nil
-}
-
-func runDeckAction(m *model, args []string) tea.Cmd {
 	return func() tea.Msg {
-		deck, err := parseDeck(args)
+		deck, err := parseDeck(m, args)
 		if err != nil {
 			return messages.NewErrorMessage(err)
 		}
@@ -214,6 +318,69 @@ func runDeckAction(m *model, args []string) tea.Cmd {
 	}
 }
 
+func runDeckSaveAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) < 3 {
+			err := errors.New("usage: deck save <name> <cards...>")
+			return messages.NewErrorMessage(err)
+		}
+
+		name := strings.ToLower(args[0])
+		deck := game.CreateDeck(args[1:])
+		err := m.game.SaveDeck(name, deck)
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		return messages.InfoMessage{Text: fmt.Sprintf("deck '%s' saved", name)}
+	}
+}
+
+func runDeckListAction(m *model) tea.Cmd {
+	return func() tea.Msg {
+		saved, err := m.game.SavedDecks()
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		names := maps.Keys(saved)
+		slices.Sort(names)
+
+		decks := make([]string, 0, len(names))
+		for _, name := range names {
+			cards := make([]string, 0, len(saved[name]))
+			for _, card := range saved[name] {
+				cards = append(cards, string(card))
+			}
+			decks = append(decks, fmt.Sprintf("%s (%s)", name, strings.Join(cards, " ")))
+		}
+
+		text := "built-in decks: " + strings.Join(game.AvailableDecks(), ", ")
+		if len(decks) > 0 {
+			text += "; saved decks: " + strings.Join(decks, ", ")
+		}
+
+		return messages.InfoMessage{Text: text}
+	}
+}
+
+func runDeckDeleteAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("usage: deck delete <name>")
+			return messages.NewErrorMessage(err)
+		}
+
+		name := strings.ToLower(args[0])
+		err := m.game.DeleteDeck(name)
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		return messages.InfoMessage{Text: fmt.Sprintf("deck '%s' deleted", name)}
+	}
+}
+
 func runSelectAction(m *model, args []string) tea.Cmd {
 	return func() tea.Msg {
 		if len(args) == 0 {
@@ -230,3 +397,531 @@ func runSelectAction(m *model, args []string) tea.Cmd {
 		return commands.SelectIssue(m.game, index)()
 	}
 }
+
+// parseIssue finds an issue by its index in the issues list
+func parseIssue(m *model, input string) (protocol.IssueID, error) {
+	if m.gameState == nil {
+		return "", errors.New("no game state")
+	}
+	index, err := strconv.Atoi(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid issue index: %s (%w)", input, err)
+	}
+	if index < 0 || index >= len(m.gameState.Issues) {
+		return "", fmt.Errorf("issue index out of range: %d", index)
+	}
+	return m.gameState.Issues[index].ID, nil
+}
+
+func runRemoveAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("no issue index provided")
+			return messages.NewErrorMessage(err)
+		}
+
+		issueID, err := parseIssue(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.RemoveIssue(issueID)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runMoveAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) < 2 {
+			err := errors.New("usage: move <issue> up|down")
+			return messages.NewErrorMessage(err)
+		}
+
+		issueID, err := parseIssue(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		var offset int
+		switch args[1] {
+		case "up":
+			offset = -1
+		case "down":
+			offset = 1
+		default:
+			err = fmt.Errorf("invalid direction: '%s', expected up or down", args[1])
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.MoveIssue(issueID, offset)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runRetitleAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) < 2 {
+			err := errors.New("usage: rename-issue <issue> <title or url>")
+			return messages.NewErrorMessage(err)
+		}
+
+		issueID, err := parseIssue(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.RenameIssue(issueID, strings.Join(args[1:], " "))
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runResetAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("no issue index provided")
+			return messages.NewErrorMessage(err)
+		}
+
+		issueID, err := parseIssue(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.ResetIssueResult(issueID)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runDeferAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("usage: defer <issue> [on|off]")
+			return messages.NewErrorMessage(err)
+		}
+
+		issueID, err := parseIssue(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		deferred := true
+		if len(args) > 1 {
+			deferred, err = parseSwitch(args[1])
+			if err != nil {
+				return messages.NewErrorMessage(err)
+			}
+		}
+
+		err = m.game.DeferIssue(issueID, deferred)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+// runSkipAction defers the active issue and deals the next one
+func runSkipAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if m.gameState == nil || m.gameState.ActiveIssue == "" {
+			err := errors.New("no active issue to skip")
+			return messages.NewErrorMessage(err)
+		}
+		err := m.game.DeferIssue(m.gameState.ActiveIssue, true)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+type issueFieldFunc func(metadata *protocol.IssueMetadata, value string)
+
+var issueFields = map[string]issueFieldFunc{
+	"description": func(metadata *protocol.IssueMetadata, value string) { metadata.Description = value },
+	"key":         func(metadata *protocol.IssueMetadata, value string) { metadata.ExternalKey = value },
+	"assignee":    func(metadata *protocol.IssueMetadata, value string) { metadata.Assignee = value },
+	"notes":       func(metadata *protocol.IssueMetadata, value string) { metadata.Notes = value },
+	"labels": func(metadata *protocol.IssueMetadata, value string) {
+		metadata.Labels = nil
+		if value != "" {
+			metadata.Labels = strings.Split(value, ",")
+		}
+	},
+}
+
+// runEditAction sets a metadata field of the issue. Empty value clears the field.
+// The issue is resolved before returning the command, as the game state may change before it's run.
+func runEditAction(m *model, args []string) tea.Cmd {
+	issueID, metadata, err := parseIssueMetadata(m, args)
+	return func() tea.Msg {
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+		err = m.game.SetIssueMetadata(issueID, metadata)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func parseIssueMetadata(m *model, args []string) (protocol.IssueID, protocol.IssueMetadata, error) {
+	if len(args) < 2 {
+		fields := maps.Keys(issueFields)
+		slices.Sort(fields)
+		err := fmt.Errorf("usage: edit <issue> <%s> [value]", strings.Join(fields, "|"))
+		return "", protocol.IssueMetadata{}, err
+	}
+
+	issueID, err := parseIssue(m, args[0])
+	if err != nil {
+		return "", protocol.IssueMetadata{}, err
+	}
+
+	setField, ok := issueFields[args[1]]
+	if !ok {
+		err = fmt.Errorf("unknown issue field: '%s'", args[1])
+		return "", protocol.IssueMetadata{}, err
+	}
+
+	issue := m.gameState.Issues.Get(issueID)
+	if issue == nil {
+		err = fmt.Errorf("issue not found: %s", issueID)
+		return "", protocol.IssueMetadata{}, err
+	}
+
+	metadata := issue.IssueMetadata
+	setField(&metadata, strings.Join(args[2:], " "))
+	return issueID, metadata, nil
+}
+
+// runExportAction saves the session report to a file.
+// By default, the report is saved in markdown to the current directory.
+func runExportAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if m.gameState == nil {
+			err := errors.New("no game state")
+			return messages.NewErrorMessage(err)
+		}
+
+		format := report.FormatMarkdown
+		if len(args) > 0 {
+			var err error
+			format, err = report.ParseFormat(args[0])
+			if err != nil {
+				return messages.NewErrorMessage(err)
+			}
+		}
+
+		path := fmt.Sprintf("2sp-report-%s.%s", time.Now().Format("20060102-150405"), format.Extension())
+		if len(args) > 1 {
+			path = args[1]
+		}
+
+		r, err := report.Build(m.gameState)
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			err = errors.Wrap(err, "failed to create report file")
+			return messages.NewErrorMessage(err)
+		}
+
+		err = report.Write(file, r, format)
+		if err != nil {
+			_ = file.Close()
+			err = errors.Wrap(err, "failed to write report")
+			return messages.NewErrorMessage(err)
+		}
+
+		err = file.Close()
+		if err != nil {
+			err = errors.Wrap(err, "failed to save report file")
+			return messages.NewErrorMessage(err)
+		}
+
+		return messages.InfoMessage{Text: fmt.Sprintf("report saved to %s", path)}
+	}
+}
+
+// runImportAction adds issues from a CSV, JSON or a plain text file.
+// The format is detected by the file extension.
+func runImportAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("usage: import <file>")
+			return messages.NewErrorMessage(err)
+		}
+
+		items, err := backlog.ReadFile(strings.Join(args, " "))
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		return commands.ImportIssues(m.game, items)()
+	}
+}
+
+// parsePlayer finds a player by ID or by name
+func parsePlayer(m *model, input string) (protocol.PlayerID, error) {
+	if m.gameState == nil {
+		return "", errors.New("no game state")
+	}
+	players := make(protocol.PlayersList, 0, len(m.gameState.Players)+len(m.gameState.PendingPlayers))
+	players = append(players, m.gameState.Players...)
+	players = append(players, m.gameState.PendingPlayers...)
+	for _, player := range players {
+		if player.ID == protocol.PlayerID(input) {
+			return player.ID, nil
+		}
+	}
+	for _, player := range players {
+		if strings.EqualFold(player.Name, input) {
+			return player.ID, nil
+		}
+	}
+	return "", fmt.Errorf("unknown player: '%s'", input)
+}
+
+func runHandoverAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("no player provided")
+			return messages.NewErrorMessage(err)
+		}
+
+		playerID, err := parsePlayer(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.Handover(playerID)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runSpectatorAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("usage: spectator <player> [on|off]")
+			return messages.NewErrorMessage(err)
+		}
+
+		playerID, err := parsePlayer(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		spectator := true
+		if len(args) > 1 {
+			spectator, err = parseSwitch(args[1])
+			if err != nil {
+				return messages.NewErrorMessage(err)
+			}
+		}
+
+		err = m.game.SetSpectator(playerID, spectator)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runKickAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("no player provided")
+			return messages.NewErrorMessage(err)
+		}
+
+		playerID, err := parsePlayer(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.Kick(playerID)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runBanAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("no player provided")
+			return messages.NewErrorMessage(err)
+		}
+
+		playerID, err := parsePlayer(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.Ban(playerID)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runRotateKeyAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.game.RotateRoomKey()
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+		return messages.RoomJoin{
+			RoomID:   m.game.RoomID(),
+			IsDealer: m.game.IsDealer(),
+		}
+	}
+}
+
+func runApproveAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("no player provided")
+			return messages.NewErrorMessage(err)
+		}
+
+		playerID, err := parsePlayer(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.ApprovePlayer(playerID)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+func runRejectAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) == 0 {
+			err := errors.New("no player provided")
+			return messages.NewErrorMessage(err)
+		}
+
+		playerID, err := parsePlayer(m, args[0])
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		err = m.game.RejectPlayer(playerID)
+		return messages.NewErrorMessage(err)
+	}
+}
+
+type settingFunc func(m *model, value string) error
+
+var settings = map[string]settingFunc{
+	"commit-reveal":  setCommitReveal,
+	"join-approval":  setJoinApproval,
+	"auto-reveal":    setAutoReveal,
+	"voting-timer":   setVotingTimer,
+	"hint-mode":      setHintMode,
+	"hint-strategy":  setHintStrategy,
+	"max-deviation":  setMaxDeviation,
+	"mean-deviation": setMeanDeviation,
+	"majority":       setMajority,
+}
+
+func parseSwitch(input string) (bool, error) {
+	switch strings.ToLower(input) {
+	case "on", "true", "yes", "1":
+		return true, nil
+	case "off", "false", "no", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid value: '%s', expected on/off", input)
+}
+
+func setCommitReveal(m *model, value string) error {
+	enabled, err := parseSwitch(value)
+	if err != nil {
+		return err
+	}
+	return m.game.SetCommitReveal(enabled)
+}
+
+func setJoinApproval(m *model, value string) error {
+	enabled, err := parseSwitch(value)
+	if err != nil {
+		return err
+	}
+	return m.game.SetJoinApproval(enabled)
+}
+
+// setAutoReveal accepts on/off, or a grace delay, e.g. "10s"
+func setAutoReveal(m *model, value string) error {
+	delay, err := time.ParseDuration(value)
+	if err == nil {
+		return m.game.SetAutoReveal(true, delay)
+	}
+
+	enabled, err := parseSwitch(value)
+	if err != nil {
+		return fmt.Errorf("invalid value: '%s', expected on/off or a delay, e.g. 10s", value)
+	}
+	if m.gameState != nil {
+		delay = m.gameState.Settings.AutoRevealDelay()
+	}
+	return m.game.SetAutoReveal(enabled, delay)
+}
+
+// setVotingTimer accepts a duration, e.g. "2m", or "off"
+func setVotingTimer(m *model, value string) error {
+	timer, err := time.ParseDuration(value)
+	if err != nil {
+		enabled, switchErr := parseSwitch(value)
+		if switchErr != nil || enabled {
+			return fmt.Errorf("invalid value: '%s', expected a duration, e.g. 2m, or off", value)
+		}
+	}
+	return m.game.SetVotingTimer(timer)
+}
+
+func setHintMode(m *model, value string) error {
+	return m.game.SetHintMode(protocol.HintMode(strings.ToLower(value)))
+}
+
+func setHintStrategy(m *model, value string) error {
+	return m.game.SetHintStrategy(protocol.HintStrategyName(strings.ToLower(value)))
+}
+
+func setMaxDeviation(m *model, value string) error {
+	return setHintThreshold(m, value, func(t *protocol.HintThresholds, v float64) {
+		t.MaxDeviation = v
+	})
+}
+
+func setMeanDeviation(m *model, value string) error {
+	return setHintThreshold(m, value, func(t *protocol.HintThresholds, v float64) {
+		t.MeanDeviation = v
+	})
+}
+
+func setMajority(m *model, value string) error {
+	return setHintThreshold(m, value, func(t *protocol.HintThresholds, v float64) {
+		t.Majority = v
+	})
+}
+
+func setHintThreshold(m *model, value string, apply func(*protocol.HintThresholds, float64)) error {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid value: '%s', expected a number", value)
+	}
+	thresholds := m.game.HintThresholds()
+	apply(&thresholds, number)
+	return m.game.SetHintThresholds(thresholds)
+}
+
+func runSetAction(m *model, args []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(args) < 2 {
+			err := errors.New("usage: set <setting> <value>")
+			return messages.NewErrorMessage(err)
+		}
+
+		setting, ok := settings[strings.ToLower(args[0])]
+		if !ok {
+			names := maps.Keys(settings)
+			slices.Sort(names)
+			err := fmt.Errorf("unknown setting: '%s', available settings: %s",
+				args[0], strings.Join(names, ", "))
+			return messages.NewErrorMessage(err)
+		}
+
+		err := setting(m, args[1])
+		return messages.NewErrorMessage(err)
+	}
+}
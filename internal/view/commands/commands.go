@@ -1,11 +1,13 @@
 package commands
 
 import (
+	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pkg/errors"
 	"github.com/six78/2-story-points-cli/internal/transport"
 	"github.com/six78/2-story-points-cli/internal/view/messages"
 	"github.com/six78/2-story-points-cli/internal/view/states"
+	"github.com/six78/2-story-points-cli/pkg/backlog"
 	"github.com/six78/2-story-points-cli/pkg/game"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 	"time"
@@ -38,13 +40,18 @@ func InitializeApp(game *game.Game, transport transport.Service) tea.Cmd {
 	}
 }
 
-func CreateNewRoom(game *game.Game) tea.Cmd {
+// CreateNewRoom creates a room with given deck. Default deck is used when deck is nil.
+func CreateNewRoom(game *game.Game, deck protocol.Deck) tea.Cmd {
 	return func() tea.Msg {
 		room, initialState, err := game.CreateNewRoom()
 		if err != nil {
 			return messages.NewErrorMessage(err)
 		}
 
+		if deck != nil {
+			initialState.Deck = deck
+		}
+
 		roomID := room.ToRoomID()
 
 		err = game.JoinRoom(roomID, initialState)
@@ -112,6 +119,27 @@ func AddIssue(game *game.Game, urlOrTitle string) tea.Cmd {
 	}
 }
 
+// ImportIssues adds the backlog items to the issues list in a single state update
+func ImportIssues(game *game.Game, items []backlog.Item) tea.Cmd {
+	return func() tea.Msg {
+		issues := make([]protocol.Issue, 0, len(items))
+		for _, item := range items {
+			issues = append(issues, item.Issue())
+		}
+
+		added, err := game.AddIssues(issues)
+		if err != nil {
+			return messages.NewErrorMessage(err)
+		}
+
+		text := fmt.Sprintf("imported %d issues", len(added))
+		if skipped := len(items) - len(added); skipped > 0 {
+			text += fmt.Sprintf(", %d duplicates skipped", skipped)
+		}
+		return messages.InfoMessage{Text: text}
+	}
+}
+
 func SelectIssue(game *game.Game, index int) tea.Cmd {
 	return func() tea.Msg {
 		err := game.SelectIssue(index)
@@ -12,6 +12,13 @@ type KeyMap struct {
 	NextIssue     key.Binding
 	PreviousIssue key.Binding
 	SelectIssue   key.Binding
+	// Issues list, dealer only
+	RemoveIssue   key.Binding
+	MoveIssueUp   key.Binding
+	MoveIssueDown key.Binding
+	RenameIssue   key.Binding
+	ResetIssue    key.Binding
+	DeferIssue    key.Binding
 	// Deck view
 	NextCard     key.Binding
 	PreviousCard key.Binding
@@ -50,6 +57,30 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("enter"),
 		key.WithHelp("Enter", "Select issue"),
 	),
+	RemoveIssue: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("X", "Remove"),
+	),
+	MoveIssueUp: key.NewBinding(
+		key.WithKeys("shift+up"),
+		key.WithHelp("Shift+↑", "Move up"),
+	),
+	MoveIssueDown: key.NewBinding(
+		key.WithKeys("shift+down"),
+		key.WithHelp("Shift+↓", "Move down"),
+	),
+	RenameIssue: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("T", "Edit title"),
+	),
+	ResetIssue: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("C", "Clear result"),
+	),
+	DeferIssue: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("S", "Skip/defer"),
+	),
 	// Deck view
 	NextCard: key.NewBinding(
 		key.WithKeys("right"),
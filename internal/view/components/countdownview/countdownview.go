@@ -0,0 +1,89 @@
+package countdownview
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/six78/2-story-points-cli/internal/view/messages"
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+var (
+	textStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffd787"))
+)
+
+type tickMessage struct {
+	time time.Time
+}
+
+// Model renders the time left until the dealer reveals the votes.
+// Votes are revealed either when everyone voted, or when the voting time is up.
+type Model struct {
+	autoRevealAt   time.Time
+	votingDeadline time.Time
+	now            time.Time
+}
+
+func New() Model {
+	return Model{}
+}
+
+func (m Model) Init() tea.Cmd {
+	return tick()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case messages.GameStateMessage:
+		m.autoRevealAt = time.Time{}
+		m.votingDeadline = time.Time{}
+		m.now = time.Now()
+		if msg.State == nil || msg.State.VoteState() != protocol.VotingState {
+			break
+		}
+		if msg.State.AutoRevealAt != 0 {
+			m.autoRevealAt = time.UnixMilli(msg.State.AutoRevealAt)
+		}
+		issue := msg.State.GetActiveIssue()
+		if issue != nil && issue.VotingDeadline != 0 {
+			m.votingDeadline = time.UnixMilli(issue.VotingDeadline)
+		}
+	case tickMessage:
+		m.now = msg.time
+		return m, tick()
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	var text string
+	switch {
+	case !m.autoRevealAt.IsZero() &&
+		(m.votingDeadline.IsZero() || m.autoRevealAt.Before(m.votingDeadline)):
+		text = "Everyone voted, revealing in " + m.remaining(m.autoRevealAt)
+	case !m.votingDeadline.IsZero() && m.now.Before(m.votingDeadline):
+		text = "Voting ends in " + m.remaining(m.votingDeadline)
+	case !m.votingDeadline.IsZero():
+		text = "Voting time is up"
+	default:
+		return ""
+	}
+
+	// Empty line to align with the hint view
+	return lipgloss.JoinVertical(lipgloss.Top, "", textStyle.Render(text))
+}
+
+// remaining is rounded up to seconds, e.g. "1m30s"
+func (m Model) remaining(deadline time.Time) string {
+	seconds := math.Ceil(math.Max(0, deadline.Sub(m.now).Seconds()))
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMessage{time: t}
+	})
+}
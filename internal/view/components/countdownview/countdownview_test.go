@@ -0,0 +1,69 @@
+package countdownview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/six78/2-story-points-cli/internal/view/messages"
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+func TestCountdown(t *testing.T) {
+	model := New()
+	require.NotNil(t, model.Init())
+	require.Empty(t, model.View())
+
+	issue := &protocol.Issue{ID: "issue"}
+	now := time.Now()
+	state := &protocol.State{
+		Issues:       protocol.IssuesList{issue},
+		ActiveIssue:  issue.ID,
+		AutoRevealAt: now.Add(3 * time.Second).UnixMilli(),
+	}
+
+	model, _ = model.Update(messages.GameStateMessage{State: state})
+	model, cmd := model.Update(tickMessage{time: now})
+	require.NotNil(t, cmd)
+	require.Equal(t, "Everyone voted, revealing in 3s", strings.TrimSpace(model.View()))
+
+	model, _ = model.Update(tickMessage{time: now.Add(2500 * time.Millisecond)})
+	require.Equal(t, "Everyone voted, revealing in 1s", strings.TrimSpace(model.View()))
+
+	// No countdown when votes are revealed
+	state.VotesRevealed = true
+	model, _ = model.Update(messages.GameStateMessage{State: state})
+	require.Empty(t, model.View())
+}
+
+func TestVotingDeadline(t *testing.T) {
+	model := New()
+
+	now := time.Now()
+	issue := &protocol.Issue{
+		ID:             "issue",
+		VotingDeadline: now.Add(90 * time.Second).UnixMilli(),
+	}
+	state := &protocol.State{
+		Issues:      protocol.IssuesList{issue},
+		ActiveIssue: issue.ID,
+	}
+
+	model, _ = model.Update(messages.GameStateMessage{State: state})
+	model, _ = model.Update(tickMessage{time: now})
+	require.Equal(t, "Voting ends in 1m30s", strings.TrimSpace(model.View()))
+
+	// The earliest reveal is shown
+	state.AutoRevealAt = now.Add(5 * time.Second).UnixMilli()
+	model, _ = model.Update(messages.GameStateMessage{State: state})
+	model, _ = model.Update(tickMessage{time: now})
+	require.Equal(t, "Everyone voted, revealing in 5s", strings.TrimSpace(model.View()))
+
+	// Deadline passed, waiting for the dealer to reveal
+	state.AutoRevealAt = 0
+	model, _ = model.Update(messages.GameStateMessage{State: state})
+	model, _ = model.Update(tickMessage{time: now.Add(2 * time.Minute)})
+	require.Equal(t, "Voting time is up", strings.TrimSpace(model.View()))
+}
@@ -6,17 +6,24 @@ import (
 	"github.com/six78/2-story-points-cli/internal/view/messages"
 )
 
-const color = lipgloss.Color("#d78700")
+const (
+	color     = lipgloss.Color("#d78700")
+	infoColor = lipgloss.Color("#808080")
+)
 
 type Model struct {
 	errorMessage string
+	infoMessage  string
 	style        lipgloss.Style
+	infoStyle    lipgloss.Style
 }
 
 func New() Model {
 	return Model{
 		errorMessage: "",
+		infoMessage:  "",
 		style:        lipgloss.NewStyle().Foreground(color),
+		infoStyle:    lipgloss.NewStyle().Foreground(infoColor),
 	}
 }
 
@@ -27,15 +34,22 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) Model {
 	switch msg := msg.(type) {
 	case messages.ErrorMessage:
+		m.infoMessage = ""
 		if msg.Err == nil {
 			m.errorMessage = ""
 		} else {
 			m.errorMessage = msg.Err.Error()
 		}
+	case messages.InfoMessage:
+		m.errorMessage = ""
+		m.infoMessage = msg.Text
 	}
 	return m
 }
 
 func (m Model) View() string {
+	if m.infoMessage != "" {
+		return m.infoStyle.Render(m.infoMessage)
+	}
 	return m.style.Render(m.errorMessage)
 }
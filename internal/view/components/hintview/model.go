@@ -2,6 +2,10 @@ package hintview
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -20,13 +24,17 @@ var (
 	MentionStyle      = textStyle.Copy().Italic(true).Foreground(config.UserColor)
 )
 
+var mentionRegexp = regexp.MustCompile(regexp.QuoteMeta(protocol.MentionPrefix) + `[\w-]+`)
+
 type Model struct {
-	hint *protocol.Hint
+	hint    *protocol.Hint
+	players []protocol.Player
 }
 
 func New() Model {
 	return Model{
-		hint: nil,
+		hint:    nil,
+		players: nil,
 	}
 }
 
@@ -46,6 +54,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if issue != nil {
 			m.hint = issue.Hint
 		}
+		m.players = msg.State.Players
 	}
 
 	return m, nil
@@ -68,11 +77,50 @@ func (m Model) View() string {
 		rejectionReason = fmt.Sprintf(" (%s)", textStyle.Render(m.hint.RejectReason))
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Top,
-		"",
-		headerStyle.Render("Recommended:")+""+voteview.Render(m.hint.Value),
-		headerStyle.Render("Acceptable:")+"  "+verdictStyle.Render(verdictText)+rejectionReason,
-		headerStyle.Render("What to do:")+"  "+textStyle.Render(m.hint.Advice),
+	rows := []string{
 		"",
-	)
+		headerStyle.Render("Recommended:") + "" + voteview.Render(m.hint.Value),
+		headerStyle.Render("Acceptable:") + "  " + verdictStyle.Render(verdictText) + rejectionReason,
+		headerStyle.Render("What to do:") + "  " + renderAdvice(m.hint.Advice, m.players),
+	}
+
+	if statistics := renderStatistics(m.hint); statistics != "" {
+		rows = append(rows, headerStyle.Render("Statistics:")+"  "+textStyle.Render(statistics))
+	}
+
+	rows = append(rows, "")
+
+	return lipgloss.JoinVertical(lipgloss.Top, rows...)
+}
+
+// renderAdvice replaces player mentions with their names
+func renderAdvice(advice string, players []protocol.Player) string {
+	return mentionRegexp.ReplaceAllStringFunc(advice, func(mention string) string {
+		playerID := protocol.PlayerID(strings.TrimPrefix(mention, protocol.MentionPrefix))
+		for _, player := range players {
+			if player.ID == playerID {
+				return MentionStyle.Render(protocol.MentionPrefix + player.Name)
+			}
+		}
+		return MentionStyle.Render(mention)
+	})
+}
+
+func renderStatistics(hint *protocol.Hint) string {
+	items := make([]string, 0, 4)
+	if hint.Stats != nil {
+		items = append(items,
+			"mean "+formatNumber(hint.Stats.Mean),
+			"median "+formatNumber(hint.Stats.Median),
+			"spread "+formatNumber(hint.Stats.Spread),
+		)
+	}
+	if hint.Abstained > 0 {
+		items = append(items, fmt.Sprintf("%d abstained", hint.Abstained))
+	}
+	return strings.Join(items, ", ")
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
 }
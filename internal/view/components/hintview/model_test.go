@@ -109,3 +109,37 @@ func TestUpdateAcceptableVote(t *testing.T) {
 		})
 	}
 }
+
+func TestStatistics(t *testing.T) {
+	hint := &protocol.Hint{
+		Acceptable: true,
+		Value:      "5",
+		Abstained:  2,
+		Stats: &protocol.HintStats{
+			Mean:   13.0 / 3,
+			Median: 5,
+			Spread: 3,
+		},
+	}
+	require.Equal(t, "mean 4.3, median 5, spread 3, 2 abstained", renderStatistics(hint))
+
+	hint.Stats = nil
+	require.Equal(t, "2 abstained", renderStatistics(hint))
+
+	hint.Abstained = 0
+	require.Empty(t, renderStatistics(hint))
+}
+
+func TestRenderAdvice(t *testing.T) {
+	players := []protocol.Player{
+		{ID: "8TtvVaHQvpWVNhYV6VfpkN", Name: "alice"},
+		{ID: "a9f1cc6e-2d5b-4dc4-9c53-5b3d4e8f0a11", Name: "bob"},
+	}
+
+	advice := "@8TtvVaHQvpWVNhYV6VfpkN and @a9f1cc6e-2d5b-4dc4-9c53-5b3d4e8f0a11, explain your estimates"
+	require.Equal(t, "@alice and @bob, explain your estimates", renderAdvice(advice, players))
+
+	// Unknown players are rendered as is
+	advice = "@unknown, explain your estimate"
+	require.Equal(t, advice, renderAdvice(advice, players))
+}
@@ -18,6 +18,7 @@ const (
 
 var (
 	highlightStyle = lipgloss.NewStyle().Foreground(config.UserColor)
+	roundsStyle    = lipgloss.NewStyle().Foreground(config.ForegroundShadeColor)
 )
 
 type Model struct {
@@ -100,6 +101,12 @@ func (m Model) View() string {
 		}
 
 		item += fmt.Sprintf("%s  %s", result, issue.TitleOrURL)
+		if issue.Deferred {
+			item += roundsStyle.Render(" (deferred)")
+		}
+		if rounds := issue.RoundsCount(); rounds > 1 {
+			item += roundsStyle.Render(fmt.Sprintf(" (%d rounds)", rounds))
+		}
 		items = append(items, style.Render(item))
 	}
 
@@ -125,6 +132,10 @@ func (m *Model) Blur() {
 	m.updateCursorFocus()
 }
 
+func (m *Model) SetCursorPosition(position int) {
+	m.cursor.SetPosition(position)
+}
+
 func (m *Model) CursorPosition() int {
 	return m.cursor.Position()
 }
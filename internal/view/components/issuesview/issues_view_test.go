@@ -107,6 +107,7 @@ func (s *Suite) TestView() {
 		&protocol.Issue{
 			ID:         "1",
 			TitleOrURL: "issue-1",
+			Deferred:   true,
 		},
 		&protocol.Issue{
 			ID:         "2",
@@ -125,6 +126,7 @@ func (s *Suite) TestView() {
 		&protocol.Issue{
 			ID:         "5",
 			TitleOrURL: "issue-5",
+			Rounds:     []protocol.VotingRound{{}},
 		},
 	}
 	model.isDealer = true
@@ -138,11 +140,16 @@ func (s *Suite) TestView() {
 	lines := strings.Split(view, "\n")
 	s.Require().Len(lines, 7)
 
+	// Lines are padded to the longest one
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+
 	s.Require().Equal("Issues:", lines[0])
-	s.Require().Equal("   -   issue-1", lines[1])
+	s.Require().Equal("   -   issue-1 (deferred)", lines[1])
 	s.Require().Equal("   ⠋   issue-2", lines[2])
 	s.Require().Equal("  13   issue-3", lines[3])
 	s.Require().Equal("   8   issue-4", lines[4])
-	s.Require().Equal(">  -   issue-5", lines[5])
+	s.Require().Equal(">  -   issue-5 (2 rounds)", lines[5])
 	s.Require().Empty(lines[6])
 }
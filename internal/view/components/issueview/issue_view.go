@@ -6,21 +6,21 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/google/go-github/v61/github"
 	"github.com/muesli/termenv"
 	"github.com/pkg/errors"
 	"github.com/six78/2-story-points-cli/internal/config"
 	"github.com/six78/2-story-points-cli/internal/view/messages"
+	"github.com/six78/2-story-points-cli/pkg/issueprovider"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 	"go.uber.org/zap"
-	"net/url"
-	"strconv"
 	"strings"
 	"time"
 )
 
 var (
 	errorStyle = lipgloss.NewStyle().Foreground(config.ForegroundShadeColor)
+	notesStyle = lipgloss.NewStyle().Foreground(config.ForegroundShadeColor).Italic(true)
+	keyStyle   = lipgloss.NewStyle().Bold(true)
 	//defaultStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#555555"))
 )
 
@@ -33,14 +33,18 @@ type Model struct {
 	issue  *protocol.Issue
 	issues map[string]*issueInfo
 
-	client  *github.Client
-	spinner spinner.Model
+	providers issueprovider.Providers
+	spinner   spinner.Model
 }
 
 type issueInfo struct {
-	err    error
-	title  *string
-	labels []labelInfo
+	err         error
+	title       *string
+	description string
+	status      string
+	labels      []labelInfo
+	// retryAt is set when the fetch failed because of the rate limit
+	retryAt time.Time
 }
 
 type labelInfo struct {
@@ -48,15 +52,15 @@ type labelInfo struct {
 	style lipgloss.Style
 }
 
-func New() Model {
+func New(providers issueprovider.Providers) Model {
 	s := spinner.New()
 	s.Spinner = spinner.MiniDot
 
 	return Model{
-		issue:   nil,
-		issues:  make(map[string]*issueInfo),
-		client:  github.NewClient(nil),
-		spinner: s,
+		issue:     nil,
+		issues:    make(map[string]*issueInfo),
+		providers: providers,
+		spinner:   s,
 	}
 }
 
@@ -82,11 +86,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if m.issue == nil {
 			break
 		}
-		_, ok := m.issues[m.issue.TitleOrURL]
-		if ok {
+		info, ok := m.issues[m.issue.TitleOrURL]
+		if ok && (info == nil || info.retryAt.IsZero() || time.Now().Before(info.retryAt)) {
 			break
 		}
-		cmd = fetchIssue(m.client, m.issue)
+		cmd = fetchIssue(m.providers, m.issue)
 		cmds = append(cmds, cmd)
 		m.issues[m.issue.TitleOrURL] = nil
 
@@ -104,9 +108,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
-	rightColumn := lipgloss.JoinVertical(lipgloss.Top,
-		m.renderRow1(),
-		m.renderInfo())
+	rows := []string{m.renderRow1()}
+	for _, row := range []string{m.renderInfo(), m.renderMetadata()} {
+		if row != "" {
+			rows = append(rows, row)
+		}
+	}
+	rightColumn := lipgloss.JoinVertical(lipgloss.Top, rows...)
 	return lipgloss.JoinHorizontal(lipgloss.Left,
 		"Issue:  \n\n", // Fill at least 3 lines
 		rightColumn)
@@ -133,7 +141,15 @@ func (m *Model) renderInfo() string {
 		return errorStyle.Render(m.spinner.View() + " fetching title")
 	}
 
+	if errors.Is(info.err, errNoProvider) {
+		return ""
+	}
+
 	if info.err != nil {
+		if !m.issue.IssueMetadata.Empty() {
+			// Metadata is informative enough without the unfurl
+			return ""
+		}
 		return errorStyle.Render(fmt.Sprintf("[%s]", info.err.Error()))
 	}
 
@@ -145,6 +161,9 @@ func (m *Model) renderInfo() string {
 	}
 
 	var labels []string
+	if info.status != "" {
+		labels = append(labels, errorStyle.Render(info.status))
+	}
 	for _, l := range info.labels {
 		if l.name == nil {
 			continue
@@ -154,93 +173,149 @@ func (m *Model) renderInfo() string {
 	}
 	row2 := strings.Join(labels, " ")
 
-	return lipgloss.JoinVertical(lipgloss.Top, row1, row2)
+	rows := []string{row1, row2}
+	if m.issue.Description == "" && info.description != "" {
+		// Description set by the dealer is shown with the metadata
+		rows = append(rows, errorStyle.Render(summary(info.description)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top, rows...)
+}
+
+// summary returns the first line of the description, truncated to fit the view
+func summary(description string) string {
+	const maxLength = 80
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxLength {
+			return string(runes[:maxLength-1]) + "…"
+		}
+		return line
+	}
+	return ""
 }
 
-type githubIssueRequest struct {
-	owner  string
-	repo   string
-	number int
+func (m *Model) renderMetadata() string {
+	if m.issue == nil {
+		return ""
+	}
+	return renderMetadata(m.issue.IssueMetadata)
 }
 
-func parseUrl(input string) (*githubIssueRequest, error) {
-	u, err := url.Parse(input)
-	if err != nil {
-		return nil, nil
+// renderMetadata shows the information set by the dealer, independently of the GitHub unfurl
+func renderMetadata(metadata protocol.IssueMetadata) string {
+	var rows []string
+
+	var tags []string
+	if metadata.ExternalKey != "" {
+		tags = append(tags, keyStyle.Render(metadata.ExternalKey))
+	}
+	if metadata.Assignee != "" {
+		tags = append(tags, "@"+metadata.Assignee)
 	}
-	if u.Host != "github.com" {
-		return nil, errors.New("only github links are unfurled")
+	for _, label := range metadata.Labels {
+		tags = append(tags, labelStyle("").Render(fmt.Sprintf("[%s]", label)))
 	}
-	path := strings.Split(u.Path, "/")
-	if len(path) != 5 {
-		return nil, errors.New("invalid github issue link")
+	if len(tags) > 0 {
+		rows = append(rows, strings.Join(tags, " "))
 	}
 
-	issueNumber, err := strconv.Atoi(path[4])
-	if err != nil {
-		return nil, errors.New("invalid github issue number")
+	if metadata.Description != "" {
+		rows = append(rows, metadata.Description)
 	}
 
-	return &githubIssueRequest{
-		owner:  path[1],
-		repo:   path[2],
-		number: issueNumber,
-	}, nil
+	if metadata.Notes != "" {
+		rows = append(rows, notesStyle.Render("Notes: "+metadata.Notes))
+	}
+
+	return strings.Join(rows, "\n")
 }
 
-func fetchIssue(client *github.Client, input *protocol.Issue) tea.Cmd {
+var errNoProvider = errors.New("no issue provider for the link")
+
+func fetchIssue(providers issueprovider.Providers, input *protocol.Issue) tea.Cmd {
 	return func() tea.Msg {
 		if input == nil {
 			return nil
 		}
-		request, err := parseUrl(input.TitleOrURL)
-		if err != nil {
+
+		provider := providers.Find(input.TitleOrURL)
+		if provider == nil {
 			return issueFetchedMessage{
 				url: input.TitleOrURL,
 				info: &issueInfo{
-					err: err,
+					err: errNoProvider,
 				},
 			}
 		}
-		if request == nil {
-			return nil
-		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 		defer cancel()
 
-		issue, _, err := client.Issues.Get(ctx, request.owner, request.repo, request.number)
+		issue, err := provider.Fetch(ctx, input.TitleOrURL)
 		if err != nil {
+			config.Logger.Debug("failed to fetch issue",
+				zap.String("provider", provider.Name()),
+				zap.Error(err))
 			return issueFetchedMessage{
-				url: input.TitleOrURL,
-				info: &issueInfo{
-					err: errors.New("failed to fetch github issue"),
-				},
+				url:  input.TitleOrURL,
+				info: fetchErrorInfo(provider.Name(), err),
 			}
 		}
 
 		labels := make([]labelInfo, len(issue.Labels))
 		for i, label := range issue.Labels {
-			labels[i].name = label.Name
+			name := label.Name
+			labels[i].name = &name
 			labels[i].style = labelStyle(label.Color)
 		}
 
 		return issueFetchedMessage{
 			url: input.TitleOrURL,
 			info: &issueInfo{
-				err:    nil,
-				title:  issue.Title,
-				labels: labels,
+				err:         nil,
+				title:       &issue.Title,
+				description: issue.Description,
+				status:      issue.Status,
+				labels:      labels,
 			},
 		}
 	}
 }
 
-func labelStyle(input *string) lipgloss.Style {
-	if input == nil {
+// fetchErrorInfo explains the failure, so that a missing token can be noticed
+func fetchErrorInfo(provider string, err error) *issueInfo {
+	var rateLimitErr *issueprovider.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		text := fmt.Sprintf("%s rate limit exceeded, retry at %s, a token gives a higher limit",
+			provider, rateLimitErr.Reset.Local().Format(time.Kitchen))
+		return &issueInfo{
+			err:     errors.New(text),
+			retryAt: rateLimitErr.Reset,
+		}
+	}
+
+	if errors.Is(err, issueprovider.ErrNotFound) {
+		return &issueInfo{
+			err: fmt.Errorf("%s issue not found, a token is required for private issues", provider),
+		}
+	}
+
+	return &issueInfo{
+		err: fmt.Errorf("failed to fetch %s issue", provider),
+	}
+}
+
+// labelStyle accepts "#rrggbb" color, empty color is rendered with the shade color
+func labelStyle(input string) lipgloss.Style {
+	if input == "" {
 		return lipgloss.NewStyle().Foreground(config.ForegroundShadeColor)
 	}
 
-	color := lipgloss.Color("#" + *input)
+	color := lipgloss.Color(input)
 	dark := colorIsDark(color)
 
 	if lipgloss.DefaultRenderer().HasDarkBackground() == dark {
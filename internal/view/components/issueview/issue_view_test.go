@@ -0,0 +1,113 @@
+package issueview
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/six78/2-story-points-cli/internal/config"
+	"github.com/six78/2-story-points-cli/pkg/issueprovider"
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+func TestRenderMetadata(t *testing.T) {
+	require.Empty(t, renderMetadata(protocol.IssueMetadata{}))
+
+	metadata := protocol.IssueMetadata{
+		Description: "Login fails on Safari",
+		Labels:      []string{"bug", "frontend"},
+		ExternalKey: "PROJ-123",
+		Assignee:    "alice",
+		Notes:       "Check with QA first",
+	}
+
+	lines := strings.Split(renderMetadata(metadata), "\n")
+	require.Equal(t, []string{
+		"PROJ-123 @alice [bug] [frontend]",
+		"Login fails on Safari",
+		"Notes: Check with QA first",
+	}, lines)
+
+	// Only present fields are rendered
+	metadata = protocol.IssueMetadata{Notes: "Skip if no time"}
+	require.Equal(t, "Notes: Skip if no time", renderMetadata(metadata))
+}
+
+func TestFetchErrorInfo(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	info := fetchErrorInfo("github", &issueprovider.RateLimitError{Reset: reset})
+	require.Contains(t, info.err.Error(), "github rate limit exceeded")
+	require.Equal(t, reset, info.retryAt)
+
+	info = fetchErrorInfo("jira", errors.Wrap(issueprovider.ErrNotFound, "request failed"))
+	require.Equal(t, "jira issue not found, a token is required for private issues", info.err.Error())
+	require.Zero(t, info.retryAt)
+
+	info = fetchErrorInfo("gitlab", errors.New("network error"))
+	require.Equal(t, "failed to fetch gitlab issue", info.err.Error())
+	require.Zero(t, info.retryAt)
+}
+
+type fakeProvider struct {
+	info *issueprovider.Info
+	err  error
+}
+
+func (p *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeProvider) Match(link string) bool {
+	return strings.HasPrefix(link, "fake://")
+}
+
+func (p *fakeProvider) Fetch(ctx context.Context, link string) (*issueprovider.Info, error) {
+	return p.info, p.err
+}
+
+func TestFetchIssue(t *testing.T) {
+	config.Logger = zap.NewNop()
+
+	provider := &fakeProvider{
+		info: &issueprovider.Info{
+			Title:       "Title",
+			Description: "Description",
+			Status:      "open",
+			Labels:      []issueprovider.Label{{Name: "bug", Color: "#ff0000"}},
+		},
+	}
+	providers := issueprovider.Providers{provider}
+
+	msg := fetchIssue(providers, &protocol.Issue{TitleOrURL: "fake://1"})()
+	fetched, ok := msg.(issueFetchedMessage)
+	require.True(t, ok)
+	require.Equal(t, "fake://1", fetched.url)
+	require.NoError(t, fetched.info.err)
+	require.Equal(t, "Title", *fetched.info.title)
+	require.Equal(t, "Description", fetched.info.description)
+	require.Equal(t, "open", fetched.info.status)
+	require.Len(t, fetched.info.labels, 1)
+	require.Equal(t, "bug", *fetched.info.labels[0].name)
+
+	// Plain titles are not unfurled
+	msg = fetchIssue(providers, &protocol.Issue{TitleOrURL: "Just a title"})()
+	fetched = msg.(issueFetchedMessage)
+	require.ErrorIs(t, fetched.info.err, errNoProvider)
+
+	provider.err = issueprovider.ErrNotFound
+	msg = fetchIssue(providers, &protocol.Issue{TitleOrURL: "fake://1"})()
+	fetched = msg.(issueFetchedMessage)
+	require.Contains(t, fetched.info.err.Error(), "fake issue not found")
+}
+
+func TestSummary(t *testing.T) {
+	require.Empty(t, summary(""))
+	require.Equal(t, "First line", summary("\n  First line \nSecond line"))
+	long := strings.Repeat("a", 100)
+	require.Equal(t, strings.Repeat("a", 79)+"…", summary(long))
+}
@@ -1,6 +1,8 @@
 package playersview
 
 import (
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
@@ -20,8 +22,9 @@ var (
 				Align(lipgloss.Center)
 	offlinePlayerStyle = onlinePlayerStyle.Copy().
 				Foreground(borderColor)
-	myNameStyle = onlinePlayerStyle.Copy().Bold(true)
-	borderStyle = lipgloss.NewStyle().Foreground(borderColor)
+	myNameStyle    = onlinePlayerStyle.Copy().Bold(true)
+	borderStyle    = lipgloss.NewStyle().Foreground(borderColor)
+	spectatorStyle = lipgloss.NewStyle().Foreground(borderColor).PaddingLeft(1)
 )
 
 type PlayerVoteResult struct {
@@ -34,6 +37,8 @@ type Model struct {
 	votes         []playervoteview.Model
 	playerNames   []string
 	playersOnline []bool
+	spectators    []string
+	pending       []string
 	playerID      protocol.PlayerID
 	playerColumn  int
 }
@@ -97,7 +102,15 @@ func (m Model) View() string {
 		Headers(m.playerNames...).
 		Rows([][]string{row}...)
 
-	return t.String()
+	lines := []string{t.String()}
+	if len(m.spectators) > 0 {
+		lines = append(lines, spectatorStyle.Render("Spectators: "+strings.Join(m.spectators, ", ")))
+	}
+	if len(m.pending) > 0 {
+		lines = append(lines, spectatorStyle.Render("Waiting for approval: "+strings.Join(m.pending, ", ")))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
 func handleNewState(m *Model, state *protocol.State) {
@@ -105,22 +118,42 @@ func handleNewState(m *Model, state *protocol.State) {
 	if state == nil {
 		m.playerNames = []string{}
 		m.votes = []playervoteview.Model{}
+		m.spectators = []string{}
+		m.pending = []string{}
 		return
 	}
 
 	m.playerNames = make([]string, 0, len(state.Players))
 	m.playersOnline = make([]bool, 0, len(state.Players))
 	m.votes = make([]playervoteview.Model, 0, len(state.Players))
+	m.spectators = make([]string, 0)
+	m.pending = make([]string, 0, len(state.PendingPlayers))
+	m.playerColumn = -1
 
-	for i, player := range state.Players {
+	for _, player := range state.Players {
 		playerName := player.Name
 		if player.ID == m.playerID {
-			m.playerColumn = i
 			playerName += " (You)"
 		}
+		// Spectators don't vote, so they don't need a column
+		if player.Spectator {
+			m.spectators = append(m.spectators, playerName)
+			continue
+		}
+		if player.ID == m.playerID {
+			m.playerColumn = len(m.playerNames)
+		}
 		m.playerNames = append(m.playerNames, playerName)
 		m.playersOnline = append(m.playersOnline, player.Online)
 		voteView := playervoteview.New(player.ID)
 		m.votes = append(m.votes, voteView)
 	}
+
+	for _, player := range state.PendingPlayers {
+		playerName := player.Name
+		if player.ID == m.playerID {
+			playerName += " (You)"
+		}
+		m.pending = append(m.pending, playerName)
+	}
 }
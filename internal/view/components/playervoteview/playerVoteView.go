@@ -52,8 +52,18 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			break
 		}
 
+		if vote.CommitmentMismatch {
+			m.voteView.Mismatch()
+			break
+		}
+
 		if vote.Value == "" {
-			m.voteView.Hide()
+			if state.VoteState() == protocol.RevealedState {
+				// Waiting for the player to open the vote commitment
+				m.voteView.Spin()
+			} else {
+				m.voteView.Hide()
+			}
 			break
 		}
 
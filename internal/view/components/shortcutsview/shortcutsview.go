@@ -27,6 +27,7 @@ type Model struct {
 	isDealer    bool
 	inRoom      bool
 	voteState   protocol.VoteState
+	allVoted    bool
 }
 
 func New() Model {
@@ -54,8 +55,10 @@ func (m Model) Update(msg tea.Msg, view states.RoomView) Model {
 	case messages.GameStateMessage:
 		if msg.State != nil {
 			m.voteState = msg.State.VoteState()
+			m.allVoted = msg.State.EveryoneVoted()
 		} else {
 			m.voteState = protocol.IdleState
+			m.allVoted = false
 		}
 	}
 
@@ -97,7 +100,11 @@ func (m Model) View() string {
 	if m.inRoom { // Row 2 (optional, dealer-only)
 		row := ""
 		if m.voteState == protocol.VotingState && m.isDealer {
-			row += keyHelp(keys.RevealVotes) + separator2
+			row += keyHelp(keys.RevealVotes)
+			if m.allVoted {
+				row += text(" (everyone voted)")
+			}
+			row += separator2
 		}
 		row += key(keys.SelectCard)
 
@@ -118,6 +125,15 @@ func (m Model) View() string {
 		rows = append(rows, row)
 	}
 
+	if m.inRoom && m.isDealer && m.roomView == states.IssuesListView { // Issues management, dealer-only
+		row := keyHelp(keys.RemoveIssue) + separator2 +
+			key(keys.MoveIssueUp) + separator1 + key(keys.MoveIssueDown) + separator1 + text("Move") + separator2 +
+			keyHelp(keys.RenameIssue) + separator2 +
+			keyHelp(keys.ResetIssue) + separator2 +
+			keyHelp(keys.DeferIssue)
+		rows = append(rows, row)
+	}
+
 	{ // Row 3
 		var row string
 
@@ -30,6 +30,7 @@ const (
 	voteValueInProgress
 	voteValueX
 	voteValueHidden
+	voteValueMismatch
 )
 
 type Model struct {
@@ -81,6 +82,8 @@ func (m Model) View() string {
 		view = "X"
 	case voteValueHidden:
 		view = "✓"
+	case voteValueMismatch:
+		view = "!"
 	}
 
 	if !m.applyStyle {
@@ -124,6 +127,12 @@ func (m *Model) Cross() {
 	m.style = &NoVoteStyle
 }
 
+// Mismatch marks a vote which doesn't match the player commitment
+func (m *Model) Mismatch() {
+	m.state = voteValueMismatch
+	m.style = &DangerVoteStyle
+}
+
 func (m *Model) Clear() {
 	m.value = ""
 	m.state = voteValueEmpty
@@ -30,6 +30,11 @@ func NewErrorMessage(err error) ErrorMessage {
 	return ErrorMessage{Err: err}
 }
 
+// InfoMessage is shown in place of errors, e.g. as a result of an action
+type InfoMessage struct {
+	Text string
+}
+
 type PlayerIDMessage struct {
 	PlayerID protocol.PlayerID
 }
@@ -42,6 +47,11 @@ type ConnectionStatus struct {
 	Status transport.ConnectionStatus
 }
 
+// IssueMoved is sent once the issue is moved to given position of the issues list
+type IssueMoved struct {
+	Position int
+}
+
 type CommandModeChange struct {
 	CommandMode bool
 }
@@ -2,7 +2,7 @@ package view
 
 import (
 	"fmt"
-	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +16,7 @@ import (
 	"github.com/six78/2-story-points-cli/internal/config"
 	"github.com/six78/2-story-points-cli/internal/transport"
 	"github.com/six78/2-story-points-cli/internal/view/commands"
+	"github.com/six78/2-story-points-cli/internal/view/components/countdownview"
 	"github.com/six78/2-story-points-cli/internal/view/components/deckview"
 	"github.com/six78/2-story-points-cli/internal/view/components/errorview"
 	"github.com/six78/2-story-points-cli/internal/view/components/eventhandler"
@@ -29,7 +30,9 @@ import (
 	"github.com/six78/2-story-points-cli/internal/view/messages"
 	"github.com/six78/2-story-points-cli/internal/view/states"
 	"github.com/six78/2-story-points-cli/internal/view/update"
+	"github.com/six78/2-story-points-cli/pkg/backlog"
 	"github.com/six78/2-story-points-cli/pkg/game"
+	"github.com/six78/2-story-points-cli/pkg/issueprovider"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 )
 
@@ -43,6 +46,8 @@ type model struct {
 	fatalError       error
 	gameState        *protocol.State
 	roomID           protocol.RoomID
+	isDealer         bool
+	securityWarning  error
 	connectionStatus transport.ConnectionStatus
 
 	// UI components state
@@ -51,6 +56,7 @@ type model struct {
 	errorView             errorview.Model
 	playersView           playersview.Model
 	hintView              hintview.Model
+	countdownView         countdownview.Model
 	shortcutsView         shortcutsview.Model
 	wakuStatusView        wakustatusview.Model
 	deckView              deckview.Model
@@ -59,6 +65,12 @@ type model struct {
 	gameEventHandler      eventhandler.Model[*protocol.State, messages.GameStateMessage]
 	transportEventHandler eventhandler.Model[transport.ConnectionStatus, messages.ConnectionStatus]
 
+	// Backlog file to import when the room is joined as a dealer
+	importFile string
+
+	// Issue to remove once the dealer presses the remove key again
+	issueToRemove protocol.IssueID
+
 	// Workaround: Used to allow pasting multiline text (list of issues)
 	disableEnterKey     bool
 	disableEnterRestart chan struct{}
@@ -69,7 +81,7 @@ type model struct {
 	spinner spinner.Model
 }
 
-func initialModel(game *game.Game, transport transport.Service) model {
+func initialModel(game *game.Game, transport transport.Service, providers issueprovider.Providers) model {
 	const initialRoomViewState = states.ActiveIssueView
 	deckView := deckview.New()
 	deckView.Focus()
@@ -90,12 +102,14 @@ func initialModel(game *game.Game, transport transport.Service) model {
 		errorView:      errorview.New(),
 		playersView:    playersview.New(),
 		hintView:       hintview.New(),
+		countdownView:  countdownview.New(),
 		shortcutsView:  shortcutsview.New(),
 		wakuStatusView: wakustatusview.New(),
 		deckView:       deckView,
-		issueView:      issueview.New(),
+		issueView:      issueview.New(providers),
 		issuesListView: issuesview.New(),
 		// Other
+		importFile:          config.ImportFile(),
 		disableEnterKey:     false,
 		disableEnterRestart: nil,
 	}
@@ -115,6 +129,7 @@ func (m model) Init() tea.Cmd {
 		m.errorView.Init(),
 		m.playersView.Init(),
 		m.hintView.Init(),
+		m.countdownView.Init(),
 		m.shortcutsView.Init(),
 		m.wakuStatusView.Init(),
 		m.deckView.Init(),
@@ -208,16 +223,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds.AppendMessage(messages.MyVote{Result: m.game.MyVote()})
 		}
 		m.gameState = msg.State
+		if !m.roomID.Empty() && m.isDealer != m.game.IsDealer() {
+			// Dealer role was passed during the game
+			cmds.AppendMessage(messages.RoomJoin{
+				RoomID:   m.roomID,
+				IsDealer: m.game.IsDealer(),
+			})
+		}
+		if roomID := m.game.RoomID(); !m.roomID.Empty() && !roomID.Empty() && roomID != m.roomID {
+			// Room key was rotated by the dealer
+			cmds.AppendMessage(messages.RoomJoin{
+				RoomID:   roomID,
+				IsDealer: m.game.IsDealer(),
+			})
+		}
+		if warning := m.game.SecurityWarning(); warning != nil && warning != m.securityWarning {
+			// Someone might be trying to spoof the dealer
+			m.securityWarning = warning
+			cmds.AppendMessage(messages.NewErrorMessage(errors.Wrap(warning, "⚠️ security warning")))
+		}
 
 	case messages.CommandModeChange:
 		m.commandMode = msg.CommandMode
 
+	case messages.IssueMoved:
+		m.issuesListView.SetCursorPosition(msg.Position)
+		cmds.AppendMessage(messages.NewErrorMessage(nil))
+
 	case messages.RoomJoin:
 		m.roomID = msg.RoomID
+		m.isDealer = msg.IsDealer
 		config.Logger.Debug("room joined",
 			zap.String("roomID", msg.RoomID.String()),
 			zap.Bool("isDealer", msg.IsDealer))
 		cmds.AppendMessage(messages.MyVote{Result: m.game.MyVote()})
+		if msg.IsDealer && m.importFile != "" {
+			cmds.AppendCommand(runImportAction(&m, []string{m.importFile}))
+			m.importFile = ""
+		}
 
 	case messages.EnableEnterKey:
 		m.disableEnterKey = false
@@ -280,6 +323,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, commands.DefaultKeyMap.RevokeVote):
 				cmds.AppendCommand(commands.PublishVote(m.game, ""))
 			}
+			if m.isDealer && m.roomViewState == states.IssuesListView {
+				cmds.AppendCommand(m.handleIssuesListKey(msg))
+			}
 		} else {
 			switch {
 			case key.Matches(msg, commands.DefaultKeyMap.NewRoom):
@@ -297,6 +343,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.errorView = m.errorView.Update(msg)
 	m.playersView, cmds.PlayersCommand = m.playersView.Update(msg)
 	m.hintView, _ = m.hintView.Update(msg)
+	m.countdownView, cmds.CountdownCommand = m.countdownView.Update(msg)
 	m.shortcutsView = m.shortcutsView.Update(msg, m.roomViewState)
 	m.wakuStatusView = m.wakuStatusView.Update(msg)
 	m.deckView = m.deckView.Update(msg)
@@ -342,6 +389,7 @@ func cursorCommand(m *model, cursor int, command func(*game.Game, protocol.VoteV
 }
 
 func toggleRoomView(m *model) {
+	m.issueToRemove = ""
 	switch m.roomViewState {
 	case states.ActiveIssueView:
 		m.roomViewState = states.IssuesListView
@@ -354,6 +402,67 @@ func toggleRoomView(m *model) {
 	}
 }
 
+// handleIssuesListKey runs dealer operations on the issue under the cursor
+func (m *model) handleIssuesListKey(msg tea.KeyMsg) tea.Cmd {
+	if m.gameState == nil {
+		return nil
+	}
+	position := m.issuesListView.CursorPosition()
+	if position < 0 || position >= len(m.gameState.Issues) {
+		return nil
+	}
+	issue := m.gameState.Issues[position]
+	index := strconv.Itoa(position)
+
+	if key.Matches(msg, commands.DefaultKeyMap.RemoveIssue) {
+		if m.issueToRemove != issue.ID {
+			m.issueToRemove = issue.ID
+			return func() tea.Msg {
+				return messages.InfoMessage{Text: fmt.Sprintf("press %s again to remove '%s'",
+					commands.DefaultKeyMap.RemoveIssue.Help().Key, issue.TitleOrURL)}
+			}
+		}
+		m.issueToRemove = ""
+		return runRemoveAction(m, []string{index})
+	}
+
+	// Any other key cancels the removal
+	m.issueToRemove = ""
+
+	switch {
+	case key.Matches(msg, commands.DefaultKeyMap.MoveIssueUp):
+		return followMovedIssue(runMoveAction(m, []string{index, "up"}), position-1)
+	case key.Matches(msg, commands.DefaultKeyMap.MoveIssueDown):
+		return followMovedIssue(runMoveAction(m, []string{index, "down"}), position+1)
+	case key.Matches(msg, commands.DefaultKeyMap.RenameIssue):
+		// Let the dealer edit the title in the command input
+		m.input.SetValue(fmt.Sprintf("%s %s %s", Retitle, index, issue.TitleOrURL))
+		return func() tea.Msg {
+			return messages.CommandModeChange{CommandMode: true}
+		}
+	case key.Matches(msg, commands.DefaultKeyMap.ResetIssue):
+		return runResetAction(m, []string{index})
+	case key.Matches(msg, commands.DefaultKeyMap.DeferIssue):
+		deferred := "on"
+		if issue.Deferred {
+			deferred = "off"
+		}
+		return runDeferAction(m, []string{index, deferred})
+	}
+	return nil
+}
+
+// followMovedIssue moves the cursor to the new position of the issue, once the move succeeded
+func followMovedIssue(move tea.Cmd, position int) tea.Cmd {
+	return func() tea.Msg {
+		msg := move()
+		if errorMessage, ok := msg.(messages.ErrorMessage); ok && errorMessage.Err != nil {
+			return msg
+		}
+		return messages.IssueMoved{Position: position}
+	}
+}
+
 func (m *model) handlePastedText(text string) (tea.Msg, tea.Cmd) {
 	if len(text) < 16 {
 		return nil, nil
@@ -373,18 +482,14 @@ func (m *model) handlePastedText(text string) (tea.Msg, tea.Cmd) {
 	}
 
 	// Try to parse as issues list
-	lines := strings.Split(text, "\n")
-	cmds := make([]tea.Cmd, 0, len(lines))
-
-	for _, line := range lines {
-		u, err := url.Parse(line)
-		if err != nil {
-			config.Logger.Warn("failed to parse issue url", zap.Error(err))
-			continue
-		}
-		cmds = append(cmds, commands.AddIssue(m.game, u.String()))
+	items, err := backlog.Parse(strings.NewReader(text), backlog.FormatText)
+	if err != nil {
+		config.Logger.Warn("failed to parse issues list", zap.Error(err))
+		return nil, nil
 	}
 
+	cmds := []tea.Cmd{commands.ImportIssues(m.game, items)}
+
 	m.disableEnterKey = true
 
 	if m.disableEnterRestart != nil {
@@ -92,10 +92,16 @@ func (m model) renderRoomCurrentIssueView() string {
 		)
 	}
 
+	// Hint is only shown after reveal, countdown only before it
+	sideView := m.hintView.View()
+	if sideView == "" {
+		sideView = m.countdownView.View()
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Top,
 		m.issueView.View(),
 		"",
-		lipgloss.JoinHorizontal(lipgloss.Left, m.playersView.View(), "  ", m.hintView.View()),
+		lipgloss.JoinHorizontal(lipgloss.Left, m.playersView.View(), "  ", sideView),
 		m.deckView.View(),
 	)
 }
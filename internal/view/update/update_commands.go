@@ -11,6 +11,7 @@ type Commands struct {
 	PlayersCommand               tea.Cmd
 	IssueViewCommand             tea.Cmd
 	IssuesListViewCommand        tea.Cmd
+	CountdownCommand             tea.Cmd
 	GameEventHandlerCommand      tea.Cmd
 	TransportEventHandlerCommand tea.Cmd
 }
@@ -38,6 +39,7 @@ func (u *Commands) Batch() tea.Cmd {
 		u.PlayersCommand,
 		u.IssueViewCommand,
 		u.IssuesListViewCommand,
+		u.CountdownCommand,
 		u.GameEventHandlerCommand,
 		u.TransportEventHandlerCommand,
 	)
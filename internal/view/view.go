@@ -5,11 +5,12 @@ import (
 	"github.com/six78/2-story-points-cli/internal/config"
 	"github.com/six78/2-story-points-cli/internal/transport"
 	"github.com/six78/2-story-points-cli/pkg/game"
+	"github.com/six78/2-story-points-cli/pkg/issueprovider"
 	"go.uber.org/zap"
 )
 
-func Run(game *game.Game, transport transport.Service) int {
-	m := initialModel(game, transport)
+func Run(game *game.Game, transport transport.Service, providers issueprovider.Providers) int {
+	m := initialModel(game, transport, providers)
 	p := tea.NewProgram(m)
 	if _, err := p.Run(); err != nil {
 		config.Logger.Error("error running program", zap.Error(err))
@@ -0,0 +1,226 @@
+package backlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+// Format of the imported backlog
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// FormatFromPath detects the format by the file extension.
+// Files with unknown extensions are read as plain text.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV
+	case ".json":
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// Item is a single backlog entry
+type Item struct {
+	Title  string   `json:"title"`
+	URL    string   `json:"url"`
+	Labels []string `json:"labels"`
+}
+
+// Issue converts the item to an issue template, without ID and votes.
+// The URL is preferred as issue TitleOrURL, so that it can be unfurled.
+// In this case the title is kept as the issue description.
+func (i Item) Issue() protocol.Issue {
+	issue := protocol.Issue{
+		TitleOrURL: i.Title,
+		IssueMetadata: protocol.IssueMetadata{
+			Labels: i.Labels,
+		},
+	}
+	if i.URL != "" {
+		issue.TitleOrURL = i.URL
+		issue.Description = i.Title
+	}
+	return issue
+}
+
+func (i Item) empty() bool {
+	return i.Title == "" && i.URL == ""
+}
+
+// ReadFile parses the backlog file in the format detected by its extension
+func ReadFile(path string) ([]Item, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open backlog file")
+	}
+	defer file.Close()
+
+	items, err := Parse(file, FormatFromPath(path))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse '%s'", path))
+	}
+	return items, nil
+}
+
+// Parse reads the backlog in given format. Empty entries are skipped.
+func Parse(r io.Reader, format Format) ([]Item, error) {
+	switch format {
+	case FormatText:
+		return parseText(r)
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatJSON:
+		return parseJSON(r)
+	}
+	return nil, fmt.Errorf("unknown backlog format: '%s'", format)
+}
+
+// parseText reads an issue title or URL per line
+func parseText(r io.Reader) ([]Item, error) {
+	var items []Item
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		items = append(items, itemFromString(line))
+	}
+	return items, scanner.Err()
+}
+
+// parseCSV reads "title,url,labels" records. The header row is optional,
+// when present, the columns can be in any order. Labels are separated by ';' or ','.
+func parseCSV(r io.Reader) ([]Item, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{"title": 0, "url": 1, "labels": 2}
+	if header, ok := parseCSVHeader(records[0]); ok {
+		columns = header
+		records = records[1:]
+	}
+
+	field := func(record []string, name string) string {
+		index, ok := columns[name]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	items := make([]Item, 0, len(records))
+	for _, record := range records {
+		item := Item{
+			Title:  field(record, "title"),
+			URL:    field(record, "url"),
+			Labels: splitLabels(field(record, "labels")),
+		}
+		if item.empty() {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func parseCSVHeader(record []string) (map[string]int, bool) {
+	columns := make(map[string]int, len(record))
+	for i, name := range record {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "title", "url", "labels":
+			columns[name] = i
+		}
+	}
+	_, title := columns["title"]
+	_, url := columns["url"]
+	return columns, title || url
+}
+
+func splitLabels(input string) []string {
+	labels := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ';' || r == ','
+	})
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); label != "" {
+			result = append(result, label)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// parseJSON reads an array of strings or {"title", "url", "labels"} objects
+func parseJSON(r io.Reader) ([]Item, error) {
+	var entries []json.RawMessage
+	err := json.NewDecoder(r).Decode(&entries)
+	if err != nil {
+		return nil, errors.Wrap(err, "expected a JSON array")
+	}
+
+	items := make([]Item, 0, len(entries))
+	for i, entry := range entries {
+		var item Item
+		if bytes.HasPrefix(bytes.TrimSpace(entry), []byte("\"")) {
+			var text string
+			err = json.Unmarshal(entry, &text)
+			item = itemFromString(strings.TrimSpace(text))
+		} else {
+			err = json.Unmarshal(entry, &item)
+			item.Title = strings.TrimSpace(item.Title)
+			item.URL = strings.TrimSpace(item.URL)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid entry %d", i))
+		}
+		if item.empty() {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func itemFromString(input string) Item {
+	if isURL(input) {
+		return Item{URL: input}
+	}
+	return Item{Title: input}
+}
+
+func isURL(input string) bool {
+	return strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
+}
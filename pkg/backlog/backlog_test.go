@@ -0,0 +1,89 @@
+package backlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const issueURL = "https://github.com/six78/2-story-points-cli/issues/1"
+
+func TestFormatFromPath(t *testing.T) {
+	require.Equal(t, FormatCSV, FormatFromPath("backlog.CSV"))
+	require.Equal(t, FormatJSON, FormatFromPath("/tmp/backlog.json"))
+	require.Equal(t, FormatText, FormatFromPath("backlog.txt"))
+	require.Equal(t, FormatText, FormatFromPath("backlog"))
+}
+
+func TestParseText(t *testing.T) {
+	input := "  First issue \n\n" + issueURL + "\r\n"
+	items, err := Parse(strings.NewReader(input), FormatText)
+	require.NoError(t, err)
+	require.Equal(t, []Item{
+		{Title: "First issue"},
+		{URL: issueURL},
+	}, items)
+}
+
+func TestParseCSV(t *testing.T) {
+	// Without header
+	input := "First issue," + issueURL + ",bug;ui\nSecond issue\n,,\n"
+	items, err := Parse(strings.NewReader(input), FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, []Item{
+		{Title: "First issue", URL: issueURL, Labels: []string{"bug", "ui"}},
+		{Title: "Second issue"},
+	}, items)
+
+	// With header, columns in custom order
+	input = "labels,URL,title\n\"bug, ui\"," + issueURL + ",First issue\n"
+	items, err = Parse(strings.NewReader(input), FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, []Item{
+		{Title: "First issue", URL: issueURL, Labels: []string{"bug", "ui"}},
+	}, items)
+}
+
+func TestParseJSON(t *testing.T) {
+	input := `["First issue", "` + issueURL + `", {"title": "Second issue", "labels": ["bug"]}, {}]`
+	items, err := Parse(strings.NewReader(input), FormatJSON)
+	require.NoError(t, err)
+	require.Equal(t, []Item{
+		{Title: "First issue"},
+		{URL: issueURL},
+		{Title: "Second issue", Labels: []string{"bug"}},
+	}, items)
+
+	_, err = Parse(strings.NewReader(`{"title": "issue"}`), FormatJSON)
+	require.Error(t, err)
+
+	_, err = Parse(strings.NewReader(`[1]`), FormatJSON)
+	require.Error(t, err)
+}
+
+func TestItemIssue(t *testing.T) {
+	issue := Item{Title: "Title", URL: issueURL, Labels: []string{"bug"}}.Issue()
+	require.Equal(t, issueURL, issue.TitleOrURL)
+	require.Equal(t, "Title", issue.Description)
+	require.Equal(t, []string{"bug"}, issue.Labels)
+
+	issue = Item{Title: "Title"}.Issue()
+	require.Equal(t, "Title", issue.TitleOrURL)
+	require.Empty(t, issue.Description)
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backlog.csv")
+	err := os.WriteFile(path, []byte("title,url\nFirst issue,\n"), 0644)
+	require.NoError(t, err)
+
+	items, err := ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []Item{{Title: "First issue"}}, items)
+
+	_, err = ReadFile(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
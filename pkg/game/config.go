@@ -4,16 +4,20 @@ import "time"
 
 type gameConfig struct {
 	PlayerName                string
+	Spectator                 bool
 	EnableSymmetricEncryption bool
 	OnlineMessagePeriod       time.Duration
 	StateMessagePeriod        time.Duration
 	PublishStateLoopEnabled   bool
+	DealerTimeout             time.Duration
 }
 
 var defaultConfig = gameConfig{
 	PlayerName:                "",
+	Spectator:                 false,
 	EnableSymmetricEncryption: true,
 	OnlineMessagePeriod:       5 * time.Second,
 	StateMessagePeriod:        30 * time.Second,
 	PublishStateLoopEnabled:   true,
+	DealerTimeout:             90 * time.Second,
 }
@@ -3,18 +3,41 @@ package game
 import (
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
+const (
+	Fibonacci         = "fibonacci"
+	ModifiedFibonacci = "modified-fibonacci"
+	TShirt            = "t-shirt"
+	PowersOfTwo       = "powers-of-two"
+	Hours             = "hours"
+	FistOfFive        = "fist-of-five"
+)
+
+// DefaultDeck is used for new rooms, and for states of older versions, which didn't transmit the deck
+const DefaultDeck = Fibonacci
+
 var fibonacciDeck = protocol.Deck{"1", "2", "3", "5", "8", "13", "21", "?"}
 
-const Fibonacci = "fibonacci"
+var modifiedFibonacciDeck = protocol.Deck{"0", "½", "1", "2", "3", "5", "8", "13", "20", "40", "100", "?", "☕"}
+
+var tShirtDeck = protocol.Deck{"XS", "S", "M", "L", "XL", "XXL", "?"}
+
+var powersOfTwoDeck = protocol.Deck{"0", "1", "2", "4", "8", "16", "32", "64", "∞", "?"}
 
-//var TShirtDeck = []protocol.VoteResult{
-//	"XS", "S", "M", "L", "XL", "XXL",
-//}
+var hoursDeck = protocol.Deck{"1h", "2h", "4h", "8h", "16h", "24h", "40h", "?"}
+
+// fistOfFiveDeck is used to vote for confidence, rather than to estimate the issue
+var fistOfFiveDeck = protocol.Deck{"1", "2", "3", "4", "5"}
 
 var decks = map[string]protocol.Deck{
-	Fibonacci: fibonacciDeck,
+	Fibonacci:         fibonacciDeck,
+	ModifiedFibonacci: modifiedFibonacciDeck,
+	TShirt:            tShirtDeck,
+	PowersOfTwo:       powersOfTwoDeck,
+	Hours:             hoursDeck,
+	FistOfFive:        fistOfFiveDeck,
 }
 
 func GetDeck(deckName string) (protocol.Deck, bool) {
@@ -22,8 +45,20 @@ func GetDeck(deckName string) (protocol.Deck, bool) {
 	return deck, ok
 }
 
+// AvailableDecks returns names of built-in decks in alphabetical order
 func AvailableDecks() []string {
-	return maps.Keys(decks)
+	names := maps.Keys(decks)
+	slices.Sort(names)
+	return names
+}
+
+// checkStateDeck validates the deck of a received state
+func checkStateDeck(state *protocol.State) error {
+	if len(state.Deck) == 0 {
+		state.Deck, _ = GetDeck(DefaultDeck)
+		return nil
+	}
+	return state.Deck.Validate()
 }
 
 func CreateDeck(votes []string) protocol.Deck {
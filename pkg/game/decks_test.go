@@ -0,0 +1,24 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+)
+
+func TestDecks(t *testing.T) {
+	names := AvailableDecks()
+	require.Len(t, names, len(decks))
+	require.True(t, slices.IsSorted(names))
+	require.Contains(t, names, DefaultDeck)
+
+	for _, name := range names {
+		deck, ok := GetDeck(name)
+		require.True(t, ok)
+		require.NoError(t, deck.Validate(), name)
+	}
+
+	_, ok := GetDeck("unknown")
+	require.False(t, ok)
+}
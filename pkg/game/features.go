@@ -6,7 +6,7 @@ type FeatureFlags struct {
 
 func defaultFeatureFlags() FeatureFlags {
 	return FeatureFlags{
-		EnableDeckSelection: false,
+		EnableDeckSelection: true,
 	}
 }
 
@@ -2,9 +2,12 @@ package game
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jonboulle/clockwork"
@@ -21,37 +24,61 @@ var (
 	ErrNoRoom = errors.New("no room")
 
 	playerOnlineTimeout = 20 * time.Second
+	issueSyncTimeout    = 10 * time.Second
 )
 
 type StateSubscription chan *protocol.State
 
+// IssueSync publishes results of finished issues, e.g. to an issue tracker
+type IssueSync interface {
+	SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error
+}
+
 type Game struct {
 	logger       *zap.Logger
 	ctx          context.Context
 	transport    transport.Service
 	storage      storage.Service
+	issueSync    IssueSync
+	issueSyncs   sync.WaitGroup // issue results being synced in background
 	clock        clockwork.Clock
 	exitRoom     chan struct{}
-	messages     chan []byte
+	exitDealer   chan struct{}
 	features     FeatureFlags
 	codeControls codeControlFlags
 
-	isDealer bool
-	player   *protocol.Player
-	myVote   protocol.VoteResult // We save our vote to show it in UI
-
-	room             *protocol.Room
-	roomID           protocol.RoomID
-	state            *protocol.State
-	stateTimestamp   int64
+	isDealer  bool
+	dealerKey *ecdsa.PrivateKey
+	player    *protocol.Player
+	playerKey *ecdsa.PrivateKey   // PlayerID is derived from this key, it's used to sign player messages
+	myVote    protocol.VoteResult // We save our vote to show it in UI
+	// myVoteSalt is used to open our vote in commit-reveal mode
+	myVoteSalt []byte
+
+	room           *protocol.Room
+	roomID         protocol.RoomID
+	state          *protocol.State
+	stateTimestamp int64
+	// roomKeyTerm and roomKeyTimestamp identify the last accepted room key message
+	roomKeyTerm      int
+	roomKeyTimestamp int64
+	// dealerSeenAt is the last time the dealer state was received, or published if this player is the dealer
+	dealerSeenAt     time.Time
 	stateSubscribers []StateSubscription
-	config           gameConfig
+	// securityWarning is set when a message, which looks like published by the dealer, is rejected
+	securityWarning error
+	config          gameConfig
+
+	// mutex protects the game, which is accessed by the UI and the game routines.
+	// Exported methods and routines hold it, unexported methods expect it to be held.
+	mutex sync.Mutex
+	// publishing tracks messages being published in background, so that Stop waits for them
+	publishing sync.WaitGroup
 }
 
 func NewGame(opts []Option) *Game {
 	game := &Game{
 		exitRoom:     nil,
-		messages:     make(chan []byte, 42),
 		features:     defaultFeatureFlags(),
 		codeControls: defaultCodeControlFlags(),
 		isDealer:     false,
@@ -98,21 +125,29 @@ func (g *Game) Initialize() error {
 		}
 	}
 
-	player, err := g.loadPlayer(g.storage)
+	player, playerKey, err := g.loadPlayer(g.storage)
 	if err != nil {
 		return err
 	}
 
+	g.playerKey = playerKey
 	g.player = &protocol.Player{
-		ID:     player.ID,
-		Name:   player.Name,
-		Online: true,
+		ID:        player.ID,
+		Name:      player.Name,
+		Online:    true,
+		Spectator: player.Spectator,
 	}
 
 	return nil
 }
 
 func (g *Game) LeaveRoom() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.leaveRoom()
+}
+
+func (g *Game) leaveRoom() {
 	if g.room != nil {
 		g.publishUserOnline(false)
 	}
@@ -121,27 +156,61 @@ func (g *Game) LeaveRoom() {
 		close(g.exitRoom)
 	}
 
+	if g.exitDealer != nil {
+		close(g.exitDealer)
+	}
+
 	g.logger.Info("left room", zap.String("roomID", g.roomID.String()))
 
 	g.exitRoom = nil
+	g.exitDealer = nil
 	g.isDealer = false
+	g.dealerKey = nil
 	g.room = nil
 	g.roomID = protocol.NewRoomID("")
 	g.state = nil
 	g.stateTimestamp = 0
+	g.roomKeyTerm = 0
+	g.roomKeyTimestamp = 0
+	g.securityWarning = nil
 	g.notifyChangedState(false)
 }
 
 func (g *Game) Stop() {
+	g.mutex.Lock()
 	for _, subscriber := range g.stateSubscribers {
 		close(subscriber)
 	}
 	g.stateSubscribers = nil
-	g.LeaveRoom()
-	// WARNING: wait for all routines to finish
+	g.leaveRoom()
+	g.mutex.Unlock()
+
+	g.publishing.Wait()
+	g.waitIssueSyncs()
+}
+
+// waitIssueSyncs waits for background issue syncs, but no longer than issueSyncTimeout
+func (g *Game) waitIssueSyncs() {
+	done := make(chan struct{})
+	go func() {
+		g.issueSyncs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-g.clock.After(issueSyncTimeout):
+		g.logger.Warn("timeout waiting for issue results to sync")
+	}
 }
 
 func (g *Game) handleMessage(payload []byte) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.dispatchMessage(payload)
+}
+
+func (g *Game) dispatchMessage(payload []byte) {
 	g.logger.Debug("handling message", zap.String("payload", string(payload)))
 
 	message := protocol.Message{}
@@ -154,9 +223,10 @@ func (g *Game) handleMessage(payload []byte) {
 
 	switch message.Type {
 	case protocol.MessageTypeState:
-		if !g.isDealer {
-			g.handleStateMessage(payload)
-		}
+		g.handleStateMessage(payload)
+
+	case protocol.MessageTypeDealerHandover:
+		g.handleDealerHandoverMessage(payload)
 
 	case protocol.MessageTypePlayerOnline:
 		if g.isDealer {
@@ -173,18 +243,39 @@ func (g *Game) handleMessage(payload []byte) {
 			g.handlePlayerVoteMessage(payload)
 		}
 
+	case protocol.MessageTypePrivate:
+		if g.isDealer {
+			g.handlePrivateMessage(payload)
+		} else {
+			g.handlePlayerPrivateMessage(payload)
+		}
+
+	case protocol.MessageTypeVoteCommit:
+		if g.isDealer {
+			g.handleVoteCommitMessage(payload)
+		}
+
+	case protocol.MessageTypeVoteOpening:
+		if g.isDealer {
+			g.handleVoteOpeningMessage(payload)
+		}
+
 	default:
 		logger.Warn("unsupported message type")
 	}
 }
 
 func (g *Game) SubscribeToStateChanges() StateSubscription {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	channel := make(StateSubscription, 10)
 	g.stateSubscribers = append(g.stateSubscribers, channel)
 	return channel
 }
 
 func (g *Game) CurrentState() *protocol.State {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	return g.state
 }
 
@@ -202,21 +293,40 @@ func (g *Game) notifyChangedState(publish bool) {
 	)
 
 	for _, subscriber := range g.stateSubscribers {
-		subscriber <- state
+		sendState(subscriber, state)
 	}
 
 	if publish {
-		go g.publishState(state)
+		g.publishState(state)
+	}
+}
+
+// sendState never blocks, as it's called with the game mutex held.
+// Only the latest state matters, so the oldest one is dropped when the subscriber is behind.
+func sendState(subscriber StateSubscription, state *protocol.State) {
+	select {
+	case subscriber <- state:
+		return
+	default:
+	}
+	select {
+	case <-subscriber:
+	default:
 	}
+	subscriber <- state
 }
 
-func (g *Game) publishOnlineState() {
+func (g *Game) publishOnlineState(exitRoom chan struct{}) {
+	g.mutex.Lock()
 	g.publishUserOnline(true)
+	g.mutex.Unlock()
 	for {
 		select {
 		case <-g.clock.After(g.config.OnlineMessagePeriod):
+			g.mutex.Lock()
 			g.publishUserOnline(true)
-		case <-g.exitRoom:
+			g.mutex.Unlock()
+		case <-exitRoom:
 			return
 		case <-g.ctx.Done():
 			return
@@ -224,17 +334,24 @@ func (g *Game) publishOnlineState() {
 	}
 }
 
-func (g *Game) publishStateLoop() {
+func (g *Game) publishStateLoop(exitRoom chan struct{}, exitDealer chan struct{}) {
 	logger := g.logger.With(zap.String("source", "state publish loop"))
 	logger.Debug("started")
 	for {
 		select {
 		case <-g.clock.After(g.config.StateMessagePeriod):
 			logger.Debug("tick")
-			g.notifyChangedState(true)
-		case <-g.exitRoom:
+			g.mutex.Lock()
+			if g.isDealer {
+				g.notifyChangedState(true)
+			}
+			g.mutex.Unlock()
+		case <-exitRoom:
 			logger.Debug("finished: room left")
 			return
+		case <-exitDealer:
+			logger.Debug("finished: dealer role passed")
+			return
 		case <-g.ctx.Done():
 			logger.Debug("finished: ctx done")
 			return
@@ -242,72 +359,112 @@ func (g *Game) publishStateLoop() {
 	}
 }
 
-func (g *Game) watchPlayersStateLoop() {
+func (g *Game) watchPlayersStateLoop(exitRoom chan struct{}, exitDealer chan struct{}) {
 	g.logger.Debug("check users state loop")
 	ticker := g.clock.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 	for {
 		select {
-		case <-g.exitRoom:
+		case <-exitRoom:
+			return
+		case <-exitDealer:
 			return
 		case <-g.ctx.Done():
 			return
 		case <-ticker.Chan():
-			if g.state == nil {
-				continue
-			}
-			stateChanged := false
-			now := g.clock.Now()
-			for i, player := range g.state.Players {
-				if !player.Online {
-					continue
-				}
-				if now.Sub(player.OnlineTime()) <= playerOnlineTimeout {
-					continue
-				}
-				g.logger.Info("marking user as offline",
-					zap.Any("name", player.Name),
-					zap.Any("lastSeenAt", player.OnlineTimestampMilliseconds),
-					zap.Any("now", now),
-				)
-				g.state.Players[i].Online = false
-				stateChanged = true
-			}
-			if stateChanged {
-				g.notifyChangedState(true)
-			}
+			g.mutex.Lock()
+			g.checkPlayersOnline()
+			g.mutex.Unlock()
 		}
 	}
 }
 
-func (g *Game) processIncomingMessages(sub *transport.MessagesSubscription) {
-	if sub.Unsubscribe != nil {
-		defer sub.Unsubscribe()
+// checkPlayersOnline marks players, who haven't sent online messages for a while, as offline
+func (g *Game) checkPlayersOnline() {
+	if !g.isDealer || g.state == nil {
+		return
+	}
+	stateChanged := false
+	now := g.clock.Now()
+	for i, player := range g.state.Players {
+		if !player.Online {
+			continue
+		}
+		if now.Sub(player.OnlineTime()) <= playerOnlineTimeout {
+			continue
+		}
+		g.logger.Info("marking user as offline",
+			zap.Any("name", player.Name),
+			zap.Any("lastSeenAt", player.OnlineTimestampMilliseconds),
+			zap.Any("now", now),
+		)
+		g.state.Players[i].Online = false
+		stateChanged = true
+	}
+	// Pending players, who went offline, are not waiting anymore
+	pendingOffline := func(player protocol.Player) bool {
+		return now.Sub(player.OnlineTime()) > playerOnlineTimeout
+	}
+	if slices.ContainsFunc(g.state.PendingPlayers, pendingOffline) {
+		g.state.PendingPlayers = slices.DeleteFunc(g.state.PendingPlayers, pendingOffline)
+		stateChanged = true
+	}
+	if stateChanged {
+		g.updateAutoReveal()
+		g.notifyChangedState(true)
 	}
+}
+
+func (g *Game) watchDealerLoop(exitRoom chan struct{}) {
+	logger := g.logger.With(zap.String("source", "watch dealer loop"))
+	ticker := g.clock.NewTicker(1 * time.Second)
+	defer ticker.Stop()
 	for {
 		select {
-		case payload, more := <-sub.Ch:
-			if !more {
-				return
-			}
-			g.handleMessage(payload)
-		case <-g.exitRoom:
+		case <-exitRoom:
 			return
 		case <-g.ctx.Done():
 			return
+		case <-ticker.Chan():
+			g.mutex.Lock()
+			if !g.isDealer && g.state != nil && g.dealerTimedOut() {
+				logger.Info("dealer timed out, taking over",
+					zap.Any("dealer", g.state.Dealer),
+					zap.Time("dealerSeenAt", g.dealerSeenAt),
+				)
+				g.takeOverDealer()
+			}
+			g.mutex.Unlock()
 		}
 	}
 }
 
-func (g *Game) loopPublishedMessages() {
+// dealerTimedOut returns true if this player is expected to take over the dealer role.
+// Each next successor waits one more DealerTimeout period, in case the previous ones are gone as well.
+func (g *Game) dealerTimedOut() bool {
+	rank := slices.Index(g.state.DealerSuccessors(), g.player.ID)
+	if rank < 0 {
+		return false
+	}
+	timeout := time.Duration(rank+1) * g.config.DealerTimeout
+	return g.clock.Now().Sub(g.dealerSeenAt) > timeout
+}
+
+func (g *Game) processIncomingMessages(sub *transport.MessagesSubscription, exitRoom chan struct{}) {
+	if sub.Unsubscribe != nil {
+		defer sub.Unsubscribe()
+	}
 	for {
 		select {
-		case <-g.exitRoom:
+		case payload, more := <-sub.Ch:
+			if !more {
+				return
+			}
+			g.handleMessage(payload)
+		case <-exitRoom:
 			return
 		case <-g.ctx.Done():
 			return
-		case payload := <-g.messages:
-			g.handleMessage(payload)
 		}
 	}
 }
@@ -322,18 +479,57 @@ func (g *Game) publishMessage(message any) error {
 		return err
 	}
 
+	// Loop message to ourselves
+	if g.isDealer {
+		g.dispatchMessage(payload)
+	}
+
+	room := g.room
+	g.publishInBackground(func() error {
+		return g.publishPayload(room, payload)
+	})
+	return nil
+}
+
+// publishInBackground sends a message without holding the game mutex, failures are only logged
+func (g *Game) publishInBackground(publish func() error) {
+	g.publishing.Add(1)
+	go func() {
+		defer g.publishing.Done()
+		err := publish()
+		if err != nil {
+			g.logger.Error("failed to publish message", zap.Error(err))
+		}
+	}()
+}
+
+func (g *Game) publishPayload(room *protocol.Room, payload []byte) error {
 	if g.config.EnableSymmetricEncryption {
-		err = g.transport.PublishPublicMessage(g.room, payload)
-	} else {
-		err = g.transport.PublishUnencryptedMessage(g.room, payload)
+		return g.transport.PublishPublicMessage(room, payload)
 	}
+	return g.transport.PublishUnencryptedMessage(room, payload)
+}
 
-	// Loop message to ourselves
+func (g *Game) publishPrivateMessage(message any, publicKey *ecdsa.PublicKey) error {
+	if g.room == nil {
+		return ErrNoRoom
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	// Loop message to ourselves, no need to encrypt it
 	if g.isDealer {
-		g.messages <- payload
+		g.dispatchMessage(payload)
 	}
 
-	return err
+	room := g.room
+	g.publishInBackground(func() error {
+		return g.transport.PublishPrivateMessage(room, payload, publicKey)
+	})
+	return nil
 }
 
 func (g *Game) publishUserOnline(online bool) {
@@ -350,7 +546,7 @@ func (g *Game) publishUserOnline(online bool) {
 	player.ApplyDeprecatedPatchOnSend()
 
 	if online {
-		message = protocol.PlayerOnlineMessage{
+		message = &protocol.PlayerOnlineMessage{
 			Player: player,
 			Message: protocol.Message{
 				Type:      protocol.MessageTypePlayerOnline,
@@ -358,7 +554,7 @@ func (g *Game) publishUserOnline(online bool) {
 			},
 		}
 	} else {
-		message = protocol.PlayerOfflineMessage{
+		message = &protocol.PlayerOfflineMessage{
 			Player: player,
 			Message: protocol.Message{
 				Type:      protocol.MessageTypePlayerOffline,
@@ -367,22 +563,40 @@ func (g *Game) publishUserOnline(online bool) {
 		}
 	}
 
-	err := g.publishMessage(message)
+	signed, err := protocol.NewSignedMessage(message, g.playerKey)
+	if err != nil {
+		g.logger.Error("failed to sign online state", zap.Error(err))
+		return
+	}
+
+	err = g.publishMessage(signed)
 	if err != nil {
 		g.logger.Error("failed to publish online state", zap.Error(err))
 	}
 }
 
 func (g *Game) PublishVote(vote protocol.VoteValue) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.publishVote(vote)
+}
+
+func (g *Game) publishVote(vote protocol.VoteValue) error {
 	if g.state.VoteState() != protocol.VotingState {
 		return errors.New("no voting in progress")
 	}
 	if vote != "" && !slices.Contains(g.state.Deck, vote) {
 		return fmt.Errorf("invalid vote")
 	}
+	if g.state.Players.IsSpectator(g.player.ID) {
+		return errors.New("spectators can't vote")
+	}
+	if g.state.Settings.CommitReveal {
+		return g.publishVoteCommit(vote)
+	}
 	g.logger.Debug("publishing vote", zap.Any("vote", vote))
 	g.myVote = *protocol.NewVoteResult(vote)
-	err := g.publishMessage(protocol.PlayerVoteMessage{
+	message := protocol.PlayerVoteMessage{
 		Message: protocol.Message{
 			Type:      protocol.MessageTypePlayerVote,
 			Timestamp: g.timestamp(),
@@ -390,7 +604,25 @@ func (g *Game) PublishVote(vote protocol.VoteValue) error {
 		PlayerID:   g.player.ID,
 		Issue:      g.state.ActiveIssue,
 		VoteResult: g.myVote,
-	})
+	}
+
+	signed, err := protocol.NewSignedMessage(message, g.playerKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign vote")
+	}
+
+	if g.state.DealerKey.Empty() {
+		// Dealer of an older version, which doesn't support private votes
+		err = g.publishMessage(signed)
+	} else {
+		var dealerKey *ecdsa.PublicKey
+		dealerKey, err = g.state.DealerKey.ECDSA()
+		if err != nil {
+			return errors.Wrap(err, "failed to parse dealer key")
+		}
+		err = g.publishPrivateMessage(signed, dealerKey)
+	}
+
 	if err != nil {
 		g.logger.Error("failed to publish vote", zap.Error(err))
 		return err
@@ -398,10 +630,107 @@ func (g *Game) PublishVote(vote protocol.VoteValue) error {
 	return nil
 }
 
+func (g *Game) publishVoteCommit(vote protocol.VoteValue) error {
+	g.logger.Debug("publishing vote commitment", zap.Any("vote", vote))
+
+	var err error
+	var commitment []byte
+	g.myVoteSalt = nil
+
+	if vote != "" {
+		g.myVoteSalt, err = protocol.GenerateVoteSalt()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate vote salt")
+		}
+		commitment = protocol.NewVoteCommitment(vote, g.myVoteSalt)
+	}
+
+	g.myVote = *protocol.NewVoteResult(vote)
+	message := protocol.PlayerVoteCommitMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeVoteCommit,
+			Timestamp: g.timestamp(),
+		},
+		PlayerID:   g.player.ID,
+		Issue:      g.state.ActiveIssue,
+		Commitment: commitment,
+	}
+
+	signed, err := protocol.NewSignedMessage(message, g.playerKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign vote commitment")
+	}
+
+	err = g.publishMessage(signed)
+	if err != nil {
+		g.logger.Error("failed to publish vote commitment", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// openMyVote publishes the opening of our vote commitment, when votes are revealed in commit-reveal mode.
+// It's called on each state change until the dealer accepts the opening.
+func (g *Game) openMyVote() {
+	if g.state == nil || !g.state.Settings.CommitReveal || g.myVoteSalt == nil {
+		return
+	}
+	if g.state.VoteState() != protocol.RevealedState {
+		return
+	}
+
+	issue := g.state.GetActiveIssue()
+	if issue == nil {
+		return
+	}
+	vote, ok := issue.Votes[g.player.ID]
+	if !ok || vote.Value != "" || vote.CommitmentMismatch {
+		return
+	}
+
+	g.logger.Debug("publishing vote opening", zap.Any("vote", g.myVote.Value))
+	message := protocol.PlayerVoteOpeningMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeVoteOpening,
+			Timestamp: g.timestamp(),
+		},
+		PlayerID: g.player.ID,
+		Issue:    g.state.ActiveIssue,
+		Vote:     g.myVote.Value,
+		Salt:     g.myVoteSalt,
+	}
+
+	signed, err := protocol.NewSignedMessage(message, g.playerKey)
+	if err != nil {
+		g.logger.Error("failed to sign vote opening", zap.Error(err))
+		return
+	}
+
+	err = g.publishMessage(signed)
+	if err != nil {
+		g.logger.Error("failed to publish vote opening", zap.Error(err))
+	}
+}
+
+// republishVote sends our vote again to a new dealer.
+// The new dealer might have missed it, because votes are hidden in the published state.
+func (g *Game) republishVote() {
+	if g.myVote.Value == "" || g.state.VoteState() != protocol.VotingState {
+		return
+	}
+	err := g.publishVote(g.myVote.Value)
+	if err != nil {
+		g.logger.Error("failed to republish vote", zap.Error(err))
+	}
+}
+
 func (g *Game) RetrieveVote() error {
-	return g.PublishVote("")
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.publishVote("")
 }
 
+// publishState is sent to the network in background, the state is not looped to the dealer
 func (g *Game) publishState(state *protocol.State) {
 	if !g.isDealer {
 		g.logger.Warn("only dealer can publish state")
@@ -413,31 +742,83 @@ func (g *Game) publishState(state *protocol.State) {
 		return
 	}
 
-	if g.HasStorage() && g.IsDealer() {
-		err := g.storage.SaveRoomState(g.RoomID(), state)
+	if g.room == nil {
+		g.logger.Error("failed to publish state", zap.Error(ErrNoRoom))
+		return
+	}
+
+	if g.HasStorage() {
+		err := g.storage.SaveRoomState(g.roomID, state)
 		if err != nil {
 			g.logger.Error("failed to save room state", zap.Error(err))
 		}
 	}
 
-	g.logger.Debug("publishing state")
-	err := g.publishMessage(protocol.GameStateMessage{
+	// Players ignore states, which are not newer than the last received one
+	timestamp := g.timestamp()
+	if timestamp <= g.stateTimestamp {
+		timestamp = g.stateTimestamp + 1
+	}
+
+	dealerKeySignature, err := protocol.SignDealerKey(state.DealerKey, g.playerKey)
+	if err != nil {
+		g.logger.Error("failed to sign dealer key", zap.Error(err))
+		return
+	}
+
+	message := protocol.GameStateMessage{
 		Message: protocol.Message{
 			Type:      protocol.MessageTypeState,
-			Timestamp: g.timestamp(),
+			Timestamp: timestamp,
 		},
-		State: *state,
-	})
+		State:              *state,
+		DealerKeySignature: dealerKeySignature,
+	}
+
+	signed, err := protocol.NewSignedMessage(message, g.dealerKey)
+	if err != nil {
+		g.logger.Error("failed to sign state", zap.Error(err))
+		return
+	}
+
+	payload, err := json.Marshal(signed)
 	if err != nil {
-		g.logger.Error("failed to publish state", zap.Error(err))
+		g.logger.Error("failed to marshal state", zap.Error(err))
+		return
 	}
+
+	g.stateTimestamp = timestamp
+	g.dealerSeenAt = g.clock.Now()
+
+	g.logger.Debug("publishing state")
+	room := g.room
+	g.publishInBackground(func() error {
+		return g.publishPayload(room, payload)
+	})
 }
 
 func (g *Game) timestamp() int64 {
 	return g.clock.Now().UnixMilli()
 }
 
+// Deal adds a new issue and starts voting for it, timeboxed with the room voting timer
 func (g *Game) Deal(input string) (protocol.IssueID, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return "", errors.New("only dealer can deal")
+	}
+	return g.dealWithTimer(input, g.state.Settings.VotingTimer())
+}
+
+// DealWithTimer is same as Deal, but with a custom voting timebox. Zero timer disables the timebox.
+func (g *Game) DealWithTimer(input string, timer time.Duration) (protocol.IssueID, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.dealWithTimer(input, timer)
+}
+
+func (g *Game) dealWithTimer(input string, timer time.Duration) (protocol.IssueID, error) {
 	if !g.isDealer {
 		return "", errors.New("only dealer can deal")
 	}
@@ -446,23 +827,29 @@ func (g *Game) Deal(input string) (protocol.IssueID, error) {
 		return "", errors.New("finish current vote to deal another issue")
 	}
 
+	if timer < 0 {
+		return "", errors.New("voting timer can't be negative")
+	}
+
 	issueID, err := g.addIssue(input)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to add issue")
 	}
 
-	err = g.SelectIssue(len(g.state.Issues) - 1)
+	err = g.selectIssue(len(g.state.Issues)-1, timer)
 
 	return issueID, err
 }
 
 func (g *Game) CreateNewRoom() (*protocol.Room, *protocol.State, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	room, err := protocol.NewRoom()
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to create a new room")
 	}
 
-	deckName := Fibonacci // FIXME: Remove hardcoded deck
+	deckName := DefaultDeck
 	deck, deckFound := GetDeck(deckName)
 	if !deckFound {
 		return nil, nil, errors.Wrap(err, fmt.Sprintf("unknown deck '%s'", deckName))
@@ -473,6 +860,7 @@ func (g *Game) CreateNewRoom() (*protocol.Room, *protocol.State, error) {
 		Deck:        deck,
 		ActiveIssue: "",
 		Issues:      make([]*protocol.Issue, 0),
+		Dealer:      g.player.ID,
 		Timestamp:   g.timestamp(),
 	}
 
@@ -480,7 +868,9 @@ func (g *Game) CreateNewRoom() (*protocol.Room, *protocol.State, error) {
 }
 
 func (g *Game) JoinRoom(roomID protocol.RoomID, state *protocol.State) error {
-	if g.RoomID() == roomID {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.roomID == roomID {
 		return errors.New("already in this room")
 	}
 	if g.room != nil {
@@ -508,8 +898,18 @@ func (g *Game) JoinRoom(roomID protocol.RoomID, state *protocol.State) error {
 	g.roomID = roomID
 	g.state = state
 	g.stateTimestamp = 0
+	g.dealerSeenAt = g.clock.Now()
 	if g.isDealer {
-		g.state.Deck, _ = GetDeck(Fibonacci) // FIXME: remove hardcoded deck
+		err = g.initializeDealerKey()
+		if err != nil {
+			return errors.Wrap(err, "failed to initialize dealer key")
+		}
+		if len(g.state.Deck) == 0 {
+			// Room state saved by an older version
+			g.state.Deck, _ = GetDeck(DefaultDeck)
+		}
+		g.state.Dealer = g.player.ID
+		g.state.DealerKey = protocol.NewPublicKey(&g.dealerKey.PublicKey)
 	}
 
 	g.resetMyVote()
@@ -531,52 +931,355 @@ func (g *Game) JoinRoom(roomID protocol.RoomID, state *protocol.State) error {
 	return nil
 }
 
-func (g *Game) startRoutines() error {
-	sub, err := g.transport.SubscribeToMessages(g.room)
+// RotateRoomKey moves the game to a new room with a new symmetric key.
+// The new room ID is sent privately to each online player of the room, so that anyone
+// who only knows the old room ID, including kicked and banned players, is left behind.
+// The old room is deleted from storage, so that it's not loaded again.
+func (g *Game) RotateRoomKey() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can rotate room key")
+	}
+
+	room, err := protocol.NewRoom()
 	if err != nil {
-		return errors.Wrap(err, "failed to subscribe to messages")
+		return errors.Wrap(err, "failed to create a new room")
 	}
 
-	go g.loopPublishedMessages()
-	go g.processIncomingMessages(sub)
+	message := protocol.RoomKeyMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeRoomKey,
+			Timestamp: g.timestamp(),
+		},
+		RoomID:     room.ToRoomID().String(),
+		DealerTerm: g.state.DealerTerm,
+	}
+	signed, err := protocol.NewSignedMessage(message, g.dealerKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign room key message")
+	}
 
-	if g.codeControls.EnablePublishOnlineState {
-		go g.publishOnlineState()
+	payload, err := json.Marshal(signed)
+	if err != nil {
+		return err
 	}
 
-	if !g.isDealer {
-		return nil
+	for _, player := range g.state.Players {
+		if player.ID == g.player.ID || !player.Online || g.playerBanned(player.ID) {
+			continue
+		}
+		err = g.publishRoomKey(player.ID, payload)
+		if err != nil {
+			// The player is left behind
+			g.logger.Warn("failed to send room key",
+				zap.Any("playerID", player.ID),
+				zap.Error(err),
+			)
+		}
 	}
 
-	if g.config.PublishStateLoopEnabled {
-		go g.publishStateLoop()
+	oldRoomID := g.roomID
+	err = g.switchRoom(room)
+	if err != nil {
+		return errors.Wrap(err, "failed to switch room")
+	}
+
+	if g.HasStorage() {
+		err = g.storage.DeleteRoom(oldRoomID)
+		if err != nil {
+			g.logger.Warn("failed to delete old room", zap.Error(err))
+		}
 	}
-	go g.watchPlayersStateLoop()
 
+	g.notifyChangedState(true)
 	return nil
 }
 
-func (g *Game) IsDealer() bool {
-	return g.isDealer
+func (g *Game) publishRoomKey(playerID protocol.PlayerID, payload []byte) error {
+	publicKey, err := playerID.PublicKey()
+	if err != nil {
+		return err
+	}
+	key, err := publicKey.ECDSA()
+	if err != nil {
+		return errors.Wrap(err, "failed to decompress player key")
+	}
+	room := g.room
+	g.publishInBackground(func() error {
+		return g.transport.PublishPrivateMessage(room, payload, key)
+	})
+	return nil
 }
 
-func (g *Game) Room() protocol.Room {
-	return *g.room
-}
+// switchRoom moves to another room, keeping the current state
+func (g *Game) switchRoom(room *protocol.Room) error {
+	roomID := room.ToRoomID()
+	if g.isDealer && g.HasStorage() {
+		err := g.storage.SaveRoomDealerKey(roomID, g.dealerKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to save dealer key")
+		}
+	}
 
-func (g *Game) RoomID() protocol.RoomID {
-	return g.roomID
+	close(g.exitRoom)
+	g.stopDealerRoutines()
+
+	g.logger.Info("switching room",
+		zap.String("from", g.roomID.String()),
+		zap.String("to", roomID.String()),
+	)
+
+	g.exitRoom = make(chan struct{})
+	g.room = room
+	g.roomID = roomID
+	g.dealerSeenAt = g.clock.Now()
+
+	return g.startRoutines()
+}
+
+func (g *Game) startRoutines() error {
+	sub, err := g.transport.SubscribeToMessages(g.room)
+	if err != nil {
+		return errors.Wrap(err, "failed to subscribe to messages")
+	}
+
+	go g.processIncomingMessages(sub, g.exitRoom)
+
+	if g.codeControls.EnablePublishOnlineState {
+		go g.publishOnlineState(g.exitRoom)
+	}
+
+	go g.watchDealerLoop(g.exitRoom)
+
+	if g.isDealer {
+		g.startDealerRoutines()
+	}
+
+	return nil
+}
+
+// initializeDealerKey loads the dealer key of current room from storage or generates a new one
+func (g *Game) initializeDealerKey() error {
+	if g.dealerKey != nil {
+		return nil
+	}
+
+	if g.HasStorage() {
+		key, err := g.storage.LoadRoomDealerKey(g.roomID)
+		if err == nil {
+			g.dealerKey = key
+			return nil
+		}
+		g.logger.Info("dealer key not found in storage", zap.Error(err))
+	}
+
+	key, err := protocol.GeneratePrivateKey()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate dealer key")
+	}
+
+	if g.HasStorage() {
+		err = g.storage.SaveRoomDealerKey(g.roomID, key)
+		if err != nil {
+			return errors.Wrap(err, "failed to save dealer key")
+		}
+	}
+
+	g.dealerKey = key
+	return nil
+}
+
+func (g *Game) startDealerRoutines() {
+	g.exitDealer = make(chan struct{})
+
+	if g.config.PublishStateLoopEnabled {
+		go g.publishStateLoop(g.exitRoom, g.exitDealer)
+	}
+	go g.watchPlayersStateLoop(g.exitRoom, g.exitDealer)
+	go g.watchRevealLoop(g.exitRoom, g.exitDealer)
+}
+
+func (g *Game) stopDealerRoutines() {
+	if g.exitDealer != nil {
+		close(g.exitDealer)
+	}
+	g.exitDealer = nil
+}
+
+// Handover passes the dealer role to given player.
+// The handover is public, so votes of the current round are not passed.
+// Players send them again to the new dealer, once it publishes the first state.
+func (g *Game) Handover(playerID protocol.PlayerID) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can handover")
+	}
+	if playerID == g.player.ID {
+		return errors.New("already a dealer")
+	}
+	player, ok := g.state.Players.Get(playerID)
+	if !ok {
+		return errors.New("player not found")
+	}
+	if !player.Online {
+		return errors.New("player is offline")
+	}
+
+	state := *g.hiddenCurrentState()
+	state.Dealer = playerID
+	state.DealerTerm++
+	state.AutoRevealAt = 0
+	if issue := state.GetActiveIssue(); issue != nil && state.VoteState() == protocol.VotingState {
+		issue.Votes = make(protocol.IssueVotes)
+	}
+
+	message := protocol.DealerHandoverMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeDealerHandover,
+			Timestamp: g.timestamp(),
+		},
+		Dealer: playerID,
+		State:  state,
+	}
+
+	signed, err := protocol.NewSignedMessage(message, g.dealerKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign dealer handover")
+	}
+
+	g.logger.Info("passing dealer role", zap.Any("dealer", playerID))
+
+	// Stay the dealer until the handover is published, the message is not looped to ourselves
+	payload, err := json.Marshal(signed)
+	if err != nil {
+		return err
+	}
+	err = g.publishPayload(g.room, payload)
+	if err != nil {
+		g.logger.Error("failed to publish dealer handover", zap.Error(err))
+		return err
+	}
+
+	// New dealer will announce its own key with the first state
+	state.DealerKey = nil
+	g.becomePlayer(&state)
+
+	return nil
+}
+
+// becomeDealer makes this player a dealer, continuing the game from given state.
+func (g *Game) becomeDealer(state *protocol.State) {
+	g.logger.Info("becoming a dealer", zap.Int("dealerTerm", state.DealerTerm))
+
+	err := g.initializeDealerKey()
+	if err != nil {
+		g.logger.Error("failed to initialize dealer key", zap.Error(err))
+		return
+	}
+
+	g.isDealer = true
+	g.state = state
+	g.state.Dealer = g.player.ID
+	g.state.DealerKey = protocol.NewPublicKey(&g.dealerKey.PublicKey)
+	g.stateTimestamp = g.timestamp()
+	if len(g.state.Deck) == 0 {
+		g.state.Deck, _ = GetDeck(DefaultDeck)
+	}
+
+	// Other players didn't send any online messages to us yet
+	now := g.timestamp()
+	for i := range g.state.Players {
+		if g.state.Players[i].Online {
+			g.state.Players[i].OnlineTimestampMilliseconds = now
+		}
+	}
+
+	g.startDealerRoutines()
+	g.notifyChangedState(true)
+}
+
+// takeOverDealer is called when the dealer stopped publishing the state.
+// Votes of the active issue are hidden in the last received state, so we drop them
+// and wait for players to send them again when they notice the dealer change.
+func (g *Game) takeOverDealer() {
+	state := *g.state
+	state.DealerTerm++
+
+	issue := state.GetActiveIssue()
+	if issue != nil && !state.VotesRevealed {
+		issue.Votes = make(protocol.IssueVotes)
+		if g.myVote.Value != "" {
+			issue.Votes[g.player.ID] = g.myVote
+		}
+	}
+
+	g.becomeDealer(&state)
+}
+
+// becomePlayer gives up the dealer role and continues as a regular player with given state.
+func (g *Game) becomePlayer(state *protocol.State) {
+	g.logger.Info("giving up dealer role", zap.Any("dealer", state.Dealer))
+
+	g.stopDealerRoutines()
+	g.isDealer = false
+	g.state = state
+	g.dealerSeenAt = g.clock.Now()
+	g.notifyChangedState(false)
+}
+
+// SecurityWarning returns the reason of the last rejected dealer message, if any
+func (g *Game) SecurityWarning() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.securityWarning
+}
+
+// rejectDealerMessage is called when a message, that only the dealer is allowed to publish,
+// has a bad signature. It might be a spoofing attempt, so the player is notified.
+func (g *Game) rejectDealerMessage(messageType protocol.MessageType, err error) {
+	g.logger.Warn("dealer message rejected",
+		zap.String("type", string(messageType)),
+		zap.Error(err),
+	)
+	g.securityWarning = errors.Wrap(err, fmt.Sprintf("rejected %s message", messageType))
+	g.notifyChangedState(false)
+}
+
+func (g *Game) IsDealer() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.isDealer
+}
+
+func (g *Game) Room() protocol.Room {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return *g.room
+}
+
+func (g *Game) RoomID() protocol.RoomID {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.roomID
 }
 
 func (g *Game) Player() protocol.Player {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	return *g.player
 }
 
 func (g *Game) MyVote() protocol.VoteResult {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	return g.myVote
 }
 
 func (g *Game) RenamePlayer(name string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	if g.HasStorage() {
 		err := g.storage.SetPlayerName(name)
 		if err != nil {
@@ -590,6 +1293,12 @@ func (g *Game) RenamePlayer(name string) error {
 }
 
 func (g *Game) Reveal() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.reveal()
+}
+
+func (g *Game) reveal() error {
 	if !g.isDealer {
 		return errors.New("only dealer can reveal cards")
 	}
@@ -599,10 +1308,410 @@ func (g *Game) Reveal() error {
 	}
 
 	g.state.VotesRevealed = true
+	g.state.AutoRevealAt = 0
+	if item := g.state.GetActiveIssue(); item != nil {
+		item.RevealedAt = g.timestamp()
+	}
+	g.notifyChangedState(true)
+	g.openMyVote()
+	return nil
+}
+
+// Revote starts a new voting round for the active issue.
+// Revealed votes are kept in the issue rounds history.
+func (g *Game) Revote() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can start a new round")
+	}
+
+	if g.state.VoteState() != protocol.RevealedState {
+		return errors.New("cannot revote when votes are not revealed")
+	}
+
+	item := g.state.GetActiveIssue()
+	if item == nil {
+		return errors.New("vote item not found in the vote list")
+	}
+
+	archiveRound(item)
+	item.VotingDeadline = g.votingDeadline(g.state.Settings.VotingTimer())
+	g.state.VotesRevealed = false
+	g.state.AutoRevealAt = 0
+	g.resetMyVote()
+	g.notifyChangedState(true)
+
+	return nil
+}
+
+// archiveRound moves revealed votes of the issue to the rounds history and clears the current round.
+// Issues revealed by older versions have no reveal time, so their votes are only cleared.
+func archiveRound(item *protocol.Issue) {
+	if item.RevealedAt != 0 {
+		item.Rounds = append(item.Rounds, protocol.VotingRound{
+			Votes:      item.Votes,
+			Hint:       item.Hint,
+			RevealedAt: item.RevealedAt,
+		})
+	}
+	item.Votes = make(protocol.IssueVotes)
+	item.Hint = nil
+	item.RevealedAt = 0
+}
+
+func (g *Game) votingDeadline(timer time.Duration) int64 {
+	if timer <= 0 {
+		return 0
+	}
+	return g.timestamp() + timer.Milliseconds()
+}
+
+// SetVotingTimer sets the default voting timebox for the next dealt issues. Zero disables the timebox.
+func (g *Game) SetVotingTimer(timer time.Duration) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can change room settings")
+	}
+	if timer < 0 {
+		return errors.New("voting timer can't be negative")
+	}
+	g.state.Settings.VotingTimerMilliseconds = timer.Milliseconds()
+	g.notifyChangedState(true)
+	return nil
+}
+
+// updateAutoReveal schedules the reveal once everyone voted.
+// The reveal is cancelled if someone revokes the vote or a new player joins during the delay.
+// The reveal itself is done by watchRevealLoop, which also reveals votes on the voting deadline.
+func (g *Game) updateAutoReveal() {
+	if !g.isDealer ||
+		!g.state.Settings.AutoReveal ||
+		g.state.VoteState() != protocol.VotingState ||
+		!g.state.EveryoneVoted() {
+		g.state.AutoRevealAt = 0
+		return
+	}
+
+	if g.state.AutoRevealAt != 0 {
+		return
+	}
+
+	g.state.AutoRevealAt = g.timestamp() + g.state.Settings.AutoRevealDelay().Milliseconds()
+}
+
+func (g *Game) watchRevealLoop(exitRoom chan struct{}, exitDealer chan struct{}) {
+	logger := g.logger.With(zap.String("source", "watch reveal loop"))
+	ticker := g.clock.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-exitRoom:
+			return
+		case <-exitDealer:
+			return
+		case <-g.ctx.Done():
+			return
+		case <-ticker.Chan():
+			g.mutex.Lock()
+			g.checkAutoReveal(logger)
+			g.mutex.Unlock()
+		}
+	}
+}
+
+// checkAutoReveal reveals the votes when everyone voted or the voting time is up
+func (g *Game) checkAutoReveal(logger *zap.Logger) {
+	if !g.isDealer || g.state == nil || g.state.VoteState() != protocol.VotingState {
+		return
+	}
+	reason := g.autoRevealReason()
+	if reason == "" {
+		return
+	}
+	logger.Info("revealing votes", zap.String("reason", reason))
+	err := g.reveal()
+	if err != nil {
+		logger.Error("failed to reveal votes", zap.Error(err))
+	}
+}
+
+// autoRevealReason returns why the votes should be revealed now, or empty string if they shouldn't
+func (g *Game) autoRevealReason() string {
+	now := g.timestamp()
+	if g.state.AutoRevealAt != 0 && now >= g.state.AutoRevealAt {
+		return "everyone voted"
+	}
+	issue := g.state.GetActiveIssue()
+	if issue != nil && issue.VotingDeadline != 0 && now >= issue.VotingDeadline {
+		return "voting time is up"
+	}
+	return ""
+}
+
+func (g *Game) SetCommitReveal(enabled bool) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can change room settings")
+	}
+	if g.state.VoteState() != protocol.IdleState && g.state.VoteState() != protocol.FinishedState {
+		return errors.New("cannot change commit-reveal mode when voting is in progress")
+	}
+	g.state.Settings.CommitReveal = enabled
+	g.notifyChangedState(true)
+	return nil
+}
+
+func (g *Game) SetHintMode(mode protocol.HintMode) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can change room settings")
+	}
+	if mode != protocol.HintModeIndex && mode != protocol.HintModeNumeric {
+		return fmt.Errorf("unknown hint mode: '%s'", mode)
+	}
+	g.state.Settings.HintMode = mode
+	g.notifyChangedState(true)
+	return nil
+}
+
+// Kick removes the player and their vote for the active issue.
+// Revealed votes of other issues and previous rounds are kept, see removePlayer.
+// Note that the player will join again with the next online message, unless banned.
+func (g *Game) Kick(playerID protocol.PlayerID) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can kick players")
+	}
+	if playerID == g.player.ID {
+		return errors.New("can't kick yourself")
+	}
+	index := g.playerIndex(playerID)
+	if index < 0 {
+		return errors.New("player not found")
+	}
+	g.removePlayer(index)
+	g.notifyChangedState(true)
+	return nil
+}
+
+// Ban kicks the player and ignores all their further messages
+func (g *Game) Ban(playerID protocol.PlayerID) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.ban(playerID)
+}
+
+func (g *Game) ban(playerID protocol.PlayerID) error {
+	if !g.isDealer {
+		return errors.New("only dealer can ban players")
+	}
+	if playerID == g.player.ID {
+		return errors.New("can't ban yourself")
+	}
+	if g.playerBanned(playerID) {
+		return errors.New("player is already banned")
+	}
+	if index := g.playerIndex(playerID); index >= 0 {
+		g.removePlayer(index)
+	}
+	if index := g.pendingPlayerIndex(playerID); index >= 0 {
+		g.state.PendingPlayers = slices.Delete(g.state.PendingPlayers, index, index+1)
+	}
+	g.state.BannedPlayers = append(g.state.BannedPlayers, playerID)
+	g.notifyChangedState(true)
+	return nil
+}
+
+// removePlayer removes the player and their vote for the active issue.
+// Votes of other issues and previous rounds are kept: they're revealed already
+// and are the record of the estimation, which results and hints were based on.
+func (g *Game) removePlayer(index int) {
+	playerID := g.state.Players[index].ID
+	g.state.Players = slices.Delete(g.state.Players, index, index+1)
+	if item := g.state.GetActiveIssue(); item != nil {
+		delete(item.Votes, playerID)
+	}
+	g.updateAutoReveal()
+}
+
+func (g *Game) playerBanned(playerID protocol.PlayerID) bool {
+	return slices.Contains(g.state.BannedPlayers, playerID)
+}
+
+// SetJoinApproval enables the approval of new players by the dealer.
+// Players, who are already in the room, stay approved.
+// When disabled, all pending players join the room.
+func (g *Game) SetJoinApproval(enabled bool) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can change room settings")
+	}
+	g.state.Settings.JoinApproval = enabled
+	if !enabled {
+		for _, player := range g.state.PendingPlayers {
+			g.addPlayer(player)
+		}
+		g.state.PendingPlayers = nil
+	}
+	g.notifyChangedState(true)
+	return nil
+}
+
+// ApprovePlayer lets a pending player join the room
+func (g *Game) ApprovePlayer(playerID protocol.PlayerID) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can approve players")
+	}
+	index := g.pendingPlayerIndex(playerID)
+	if index < 0 {
+		return errors.New("player is not waiting for approval")
+	}
+	player := g.state.PendingPlayers[index]
+	g.state.PendingPlayers = slices.Delete(g.state.PendingPlayers, index, index+1)
+	g.addPlayer(player)
+	g.notifyChangedState(true)
+	return nil
+}
+
+// RejectPlayer declines the join request of a pending player.
+// The player is banned, otherwise the request would be repeated with the next online message.
+func (g *Game) RejectPlayer(playerID protocol.PlayerID) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can reject players")
+	}
+	if g.pendingPlayerIndex(playerID) < 0 {
+		return errors.New("player is not waiting for approval")
+	}
+	return g.ban(playerID)
+}
+
+// knock puts a new player to the pending list, or refreshes the pending request
+func (g *Game) knock(player protocol.Player) {
+	player.Online = true
+	player.OnlineTimestampMilliseconds = g.timestamp()
+
+	index := g.pendingPlayerIndex(player.ID)
+	if index >= 0 {
+		changed := g.state.PendingPlayers[index].Name != player.Name
+		g.state.PendingPlayers[index] = player
+		if changed {
+			g.notifyChangedState(true)
+		}
+		return
+	}
+
+	g.state.PendingPlayers = append(g.state.PendingPlayers, player)
+	g.notifyChangedState(true)
+	g.logger.Info("player is waiting for approval", zap.Any("player", player))
+}
+
+func (g *Game) addPlayer(player protocol.Player) {
+	player.Online = true
+	player.OnlineTimestampMilliseconds = g.timestamp()
+	g.state.Players = append(g.state.Players, player)
+	g.updateAutoReveal()
+	g.logger.Info("player joined", zap.Any("player", player))
+}
+
+func (g *Game) pendingPlayerIndex(playerID protocol.PlayerID) int {
+	return slices.IndexFunc(g.state.PendingPlayers, func(player protocol.Player) bool {
+		return player.ID == playerID
+	})
+}
+
+// SetSpectator marks the player as a spectator, or allows a spectator to vote again.
+// Votes of a new spectator for the active issue are removed.
+func (g *Game) SetSpectator(playerID protocol.PlayerID, spectator bool) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can change spectators")
+	}
+	index := g.playerIndex(playerID)
+	if index < 0 {
+		return errors.New("player not found")
+	}
+	g.state.Players[index].Spectator = spectator
+	if spectator {
+		if item := g.state.GetActiveIssue(); item != nil {
+			delete(item.Votes, playerID)
+		}
+	}
+	g.updateAutoReveal()
+	g.notifyChangedState(true)
+	return nil
+}
+
+// SetAutoReveal enables automatic reveal, once everyone voted and the delay passed
+func (g *Game) SetAutoReveal(enabled bool, delay time.Duration) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can change room settings")
+	}
+	if delay < 0 {
+		return errors.New("auto reveal delay can't be negative")
+	}
+	g.state.Settings.AutoReveal = enabled
+	g.state.Settings.AutoRevealDelayMilliseconds = delay.Milliseconds()
+	g.state.AutoRevealAt = 0
+	g.updateAutoReveal()
+	g.notifyChangedState(true)
+	return nil
+}
+
+func (g *Game) SetHintStrategy(name protocol.HintStrategyName) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can change room settings")
+	}
+	if _, err := NewHintStrategy(name, g.hintThresholds()); err != nil {
+		return err
+	}
+	g.state.Settings.HintStrategy = name
+	g.notifyChangedState(true)
+	return nil
+}
+
+func (g *Game) SetHintThresholds(thresholds protocol.HintThresholds) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can change room settings")
+	}
+	if err := thresholds.Validate(); err != nil {
+		return err
+	}
+	g.state.Settings.HintThresholds = &thresholds
 	g.notifyChangedState(true)
 	return nil
 }
 
+// HintThresholds returns the thresholds of current room, or the default ones if not set
+func (g *Game) HintThresholds() protocol.HintThresholds {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.state == nil {
+		return DefaultHintThresholds
+	}
+	return g.hintThresholds()
+}
+
+func (g *Game) hintThresholds() protocol.HintThresholds {
+	return RoomHintThresholds(g.state.Settings)
+}
+
 func (g *Game) hiddenCurrentState() *protocol.State {
 	if g.state == nil {
 		return nil
@@ -633,6 +1742,8 @@ func (g *Game) hiddenCurrentState() *protocol.State {
 }
 
 func (g *Game) SetDeck(deck protocol.Deck) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	if !g.features.EnableDeckSelection {
 		return errors.New("deck selection is disabled")
 	}
@@ -642,12 +1753,71 @@ func (g *Game) SetDeck(deck protocol.Deck) error {
 	if g.state.VoteState() != protocol.IdleState && g.state.VoteState() != protocol.FinishedState {
 		return errors.New("cannot set deck when voting is in progress")
 	}
+	err := deck.Validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid deck")
+	}
 	g.state.Deck = deck
 	g.notifyChangedState(true)
 	return nil
 }
 
+// SavedDecks returns user-defined decks, saved in the storage
+func (g *Game) SavedDecks() (map[string]protocol.Deck, error) {
+	if !g.HasStorage() {
+		return nil, errors.New("no storage to load decks from")
+	}
+	return g.storage.LoadDecks()
+}
+
+// FindDeck looks for a built-in deck or a saved deck with given name
+func (g *Game) FindDeck(name string) (protocol.Deck, bool) {
+	deck, ok := GetDeck(name)
+	if ok || !g.HasStorage() {
+		return deck, ok
+	}
+
+	saved, err := g.storage.LoadDecks()
+	if err != nil {
+		g.logger.Warn("failed to load saved decks", zap.Error(err))
+		return nil, false
+	}
+
+	deck, ok = saved[name]
+	return deck, ok
+}
+
+// SaveDeck saves a user-defined deck, so that it can be used by name in other rooms
+func (g *Game) SaveDeck(name string, deck protocol.Deck) error {
+	if !g.HasStorage() {
+		return errors.New("no storage to save the deck to")
+	}
+	if name == "" {
+		return errors.New("deck name can't be empty")
+	}
+	if _, ok := GetDeck(name); ok {
+		return fmt.Errorf("can't overwrite built-in deck '%s'", name)
+	}
+	err := deck.Validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid deck")
+	}
+	return g.storage.SaveDeck(name, deck)
+}
+
+func (g *Game) DeleteDeck(name string) error {
+	if !g.HasStorage() {
+		return errors.New("no storage to delete the deck from")
+	}
+	if _, ok := GetDeck(name); ok {
+		return fmt.Errorf("can't delete built-in deck '%s'", name)
+	}
+	return g.storage.DeleteDeck(name)
+}
+
 func (g *Game) Finish(result protocol.VoteValue) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	if !g.isDealer {
 		return errors.New("only dealer can finish")
 	}
@@ -664,22 +1834,60 @@ func (g *Game) Finish(result protocol.VoteValue) error {
 	}
 
 	item.Result = &result
-	g.state.ActiveIssue = g.state.Issues.GetNextIssueToDeal(g.state.ActiveIssue)
+	g.syncIssueResult(item)
+	return g.dealNextIssue(item.ID)
+}
+
+// dealNextIssue deals the next issue without a result after given one, with the room voting timer.
+// Voting stops when there is nothing else to deal.
+func (g *Game) dealNextIssue(issueID protocol.IssueID) error {
 	g.state.VotesRevealed = false
 	g.resetMyVote()
-	g.notifyChangedState(true)
 
+	next := g.state.Issues.Index(g.state.Issues.GetNextIssueToDeal(issueID))
+	if next >= 0 {
+		return g.selectIssue(next, g.state.Settings.VotingTimer())
+	}
+
+	g.state.ActiveIssue = ""
+	g.state.AutoRevealAt = 0
+	g.notifyChangedState(true)
 	return nil
 }
 
+// syncIssueResult runs the issue sync in background, failures are only logged
+func (g *Game) syncIssueResult(item *protocol.Issue) {
+	if g.issueSync == nil {
+		return
+	}
+
+	issue := *item
+	issue.Votes = CountedVotes(g.state, item)
+	deck := slices.Clone(g.state.Deck)
+
+	g.issueSyncs.Add(1)
+	go func() {
+		defer g.issueSyncs.Done()
+		err := g.issueSync.SyncResult(g.ctx, deck, issue)
+		if err != nil {
+			g.logger.Error("failed to sync issue result",
+				zap.String("titleOrUrl", issue.TitleOrURL),
+				zap.Error(err))
+		}
+	}()
+}
+
 func (g *Game) resetMyVote() {
 	g.myVote = protocol.VoteResult{
 		Value:     "",
 		Timestamp: 0,
 	}
+	g.myVoteSalt = nil
 }
 
 func (g *Game) AddIssue(titleOrURL string) (protocol.IssueID, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	if !g.isDealer {
 		return "", errors.New("only dealer can add issues")
 	}
@@ -691,16 +1899,48 @@ func (g *Game) AddIssue(titleOrURL string) (protocol.IssueID, error) {
 	return issueID, nil
 }
 
+// AddIssues adds a batch of issues in a single state update.
+// Only TitleOrURL and metadata are taken from given issues.
+// Issues that already exist in the list are skipped.
+func (g *Game) AddIssues(issues []protocol.Issue) ([]protocol.IssueID, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return nil, errors.New("only dealer can add issues")
+	}
+
+	var err error
+	var issueID protocol.IssueID
+	added := make([]protocol.IssueID, 0, len(issues))
+
+	for _, item := range issues {
+		titleOrURL := strings.TrimSpace(item.TitleOrURL)
+		if titleOrURL == "" || g.state.Issues.HasTitleOrURL(titleOrURL) {
+			continue
+		}
+		issueID, err = g.addIssue(titleOrURL)
+		if err != nil {
+			break
+		}
+		issue := g.state.Issues.Get(issueID)
+		issue.IssueMetadata = item.IssueMetadata
+		issue.Labels = normalizeLabels(item.Labels)
+		added = append(added, issueID)
+	}
+
+	if len(added) > 0 {
+		g.notifyChangedState(true)
+	}
+	return added, err
+}
+
 func (g *Game) addIssue(titleOrURL string) (protocol.IssueID, error) {
 	issueID, err := GenerateIssueID()
 	if err != nil {
 		return "", errors.New("failed to generate UUID")
 	}
 
-	issueExist := slices.ContainsFunc(g.state.Issues, func(item *protocol.Issue) bool {
-		return item.TitleOrURL == titleOrURL
-	})
-	if issueExist {
+	if g.state.Issues.HasTitleOrURL(titleOrURL) {
 		return "", errors.New("issue already exists")
 	}
 
@@ -716,11 +1956,171 @@ func (g *Game) addIssue(titleOrURL string) (protocol.IssueID, error) {
 	return issue.ID, nil
 }
 
+// RemoveIssue deletes the issue from the list.
+// When the active issue is removed, the voting is cancelled.
+func (g *Game) RemoveIssue(issueID protocol.IssueID) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can remove issues")
+	}
+	index := g.state.Issues.Index(issueID)
+	if index < 0 {
+		return errors.New("issue not found")
+	}
+
+	g.state.Issues = slices.Delete(g.state.Issues, index, index+1)
+	if g.state.ActiveIssue == issueID {
+		g.state.ActiveIssue = ""
+		g.state.VotesRevealed = false
+		g.state.AutoRevealAt = 0
+		g.resetMyVote()
+	}
+	g.notifyChangedState(true)
+	return nil
+}
+
+// MoveIssue moves the issue by given offset in the list, e.g. -1 to move it up
+func (g *Game) MoveIssue(issueID protocol.IssueID, offset int) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can move issues")
+	}
+	index := g.state.Issues.Index(issueID)
+	if index < 0 {
+		return errors.New("issue not found")
+	}
+	newIndex := index + offset
+	if newIndex < 0 || newIndex >= len(g.state.Issues) {
+		return errors.New("issue can't be moved out of the list")
+	}
+
+	issue := g.state.Issues[index]
+	g.state.Issues = slices.Delete(g.state.Issues, index, index+1)
+	g.state.Issues = slices.Insert(g.state.Issues, newIndex, issue)
+	g.notifyChangedState(true)
+	return nil
+}
+
+func (g *Game) RenameIssue(issueID protocol.IssueID, titleOrURL string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can rename issues")
+	}
+	if titleOrURL == "" {
+		return errors.New("empty issue title")
+	}
+	issue := g.state.Issues.Get(issueID)
+	if issue == nil {
+		return errors.New("issue not found")
+	}
+	issueExist := slices.ContainsFunc(g.state.Issues, func(item *protocol.Issue) bool {
+		return item.ID != issueID && item.TitleOrURL == titleOrURL
+	})
+	if issueExist {
+		return errors.New("issue already exists")
+	}
+
+	issue.TitleOrURL = titleOrURL
+	g.notifyChangedState(true)
+	return nil
+}
+
+// ResetIssueResult clears the result, so that the issue is dealt again
+func (g *Game) ResetIssueResult(issueID protocol.IssueID) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can reset issue result")
+	}
+	issue := g.state.Issues.Get(issueID)
+	if issue == nil {
+		return errors.New("issue not found")
+	}
+	if issue.Result == nil {
+		return errors.New("issue has no result")
+	}
+
+	// Keep the finished round, as if the issue was dealt again
+	archiveRound(issue)
+	issue.Result = nil
+	g.notifyChangedState(true)
+	return nil
+}
+
+// DeferIssue postpones the issue until all other issues are estimated.
+// Deferring the active issue skips it and deals the next one.
+func (g *Game) DeferIssue(issueID protocol.IssueID, deferred bool) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can defer issues")
+	}
+	issue := g.state.Issues.Get(issueID)
+	if issue == nil {
+		return errors.New("issue not found")
+	}
+	if deferred && issue.Result != nil {
+		return errors.New("issue is already estimated")
+	}
+
+	issue.Deferred = deferred
+
+	if deferred && g.state.ActiveIssue == issueID {
+		return g.dealNextIssue(issueID)
+	}
+
+	g.notifyChangedState(true)
+	return nil
+}
+
+// SetIssueMetadata replaces the metadata of the issue.
+// Labels are trimmed, empty and duplicate labels are dropped.
+func (g *Game) SetIssueMetadata(issueID protocol.IssueID, metadata protocol.IssueMetadata) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.isDealer {
+		return errors.New("only dealer can edit issues")
+	}
+	issue := g.state.Issues.Get(issueID)
+	if issue == nil {
+		return errors.New("issue not found")
+	}
+
+	metadata.Labels = normalizeLabels(metadata.Labels)
+	issue.IssueMetadata = metadata
+	g.notifyChangedState(true)
+	return nil
+}
+
+// normalizeLabels trims the labels and removes empty and duplicate ones
+func normalizeLabels(input []string) []string {
+	labels := make([]string, 0, len(input))
+	for _, label := range input {
+		label = strings.TrimSpace(label)
+		if label == "" || slices.Contains(labels, label) {
+			continue
+		}
+		labels = append(labels, label)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
 func (g *Game) SelectIssue(index int) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 	if !g.isDealer {
 		return errors.New("only dealer can deal")
 	}
+	return g.selectIssue(index, g.state.Settings.VotingTimer())
+}
 
+func (g *Game) selectIssue(index int, timer time.Duration) error {
 	if g.state.VoteState() == protocol.RevealedState {
 		return errors.New("cannot deal when voting is in progress")
 	}
@@ -729,9 +2129,15 @@ func (g *Game) SelectIssue(index int) error {
 		return errors.New("invalid issue deckIndex")
 	}
 
+	// Keep the previous round when the issue is dealt again
+	archiveRound(g.state.Issues[index])
+
 	g.state.Issues[index].Result = nil
+	g.state.Issues[index].Deferred = false
 	g.state.Issues[index].Votes = make(protocol.IssueVotes)
+	g.state.Issues[index].VotingDeadline = g.votingDeadline(timer)
 	g.state.ActiveIssue = g.state.Issues[index].ID
+	g.state.AutoRevealAt = 0
 	g.notifyChangedState(true)
 
 	return nil
@@ -743,26 +2149,21 @@ func (g *Game) playerIndex(playerID protocol.PlayerID) int {
 	})
 }
 
-func (g *Game) loadPlayer(s storage.Service) (*protocol.Player, error) {
-	var err error
+func (g *Game) loadPlayer(s storage.Service) (*protocol.Player, *ecdsa.PrivateKey, error) {
 	var player protocol.Player
 
-	// Load ID
-	if !nilStorage(s) {
-		player.ID = s.PlayerID()
+	key, err := loadPlayerKey(s)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if player.ID == "" {
-		player.ID, err = GeneratePlayerID()
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to generate player ID")
-		}
+	// Load ID. It's derived from the key, IDs generated by older versions are replaced.
+	player.ID = protocol.NewPlayerID(&key.PublicKey)
 
-		if !nilStorage(s) {
-			err = s.SetPlayerID(player.ID)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to save player ID")
-			}
+	if !nilStorage(s) && s.PlayerID() != player.ID {
+		err = s.SetPlayerID(player.ID)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to save player ID")
 		}
 	}
 
@@ -773,7 +2174,32 @@ func (g *Game) loadPlayer(s storage.Service) (*protocol.Player, error) {
 		player.Name = s.PlayerName()
 	}
 
-	return &player, nil
+	player.Spectator = g.config.Spectator
+
+	return &player, key, nil
+}
+
+func loadPlayerKey(s storage.Service) (*ecdsa.PrivateKey, error) {
+	if !nilStorage(s) {
+		key, err := s.LoadPlayerKey()
+		if err == nil {
+			return key, nil
+		}
+	}
+
+	key, err := protocol.GeneratePrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate player key")
+	}
+
+	if !nilStorage(s) {
+		err = s.SavePlayerKey(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to save player key")
+		}
+	}
+
+	return key, nil
 }
 
 func nilStorage(s storage.Service) bool {
@@ -816,9 +2242,16 @@ func (g *Game) fillActiveIssueHint() {
 		return
 	}
 
+	votes := CountedVotes(g.state, item)
+
+	item.Hint = nil
+	if len(votes) == 0 {
+		return
+	}
+
 	var err error
-	item.Hint, err = GetResultHint(g.state.Deck, item.Votes)
-	if err != nil {
+	item.Hint, err = GetRoomHint(g.state.Settings, g.state.Deck, votes)
+	if err != nil && !errors.Is(err, ErrNoNumericVotes) {
 		g.logger.Error("failed to generate hint", zap.Error(err))
 	}
 }
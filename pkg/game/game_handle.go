@@ -1,7 +1,8 @@
 package game
 
 import (
-	"encoding/json"
+	"bytes"
+	"github.com/pkg/errors"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
@@ -9,7 +10,7 @@ import (
 
 func (g *Game) handleStateMessage(payload []byte) {
 	var message protocol.GameStateMessage
-	err := json.Unmarshal(payload, &message)
+	signed, err := protocol.UnmarshalSignedMessage(payload, &message)
 	if err != nil {
 		g.logger.Error("failed to unmarshal message", zap.Error(err))
 		return
@@ -17,41 +18,269 @@ func (g *Game) handleStateMessage(payload []byte) {
 
 	g.logger.Info("state message received", zap.Any("state", message.State))
 
-	if g.state != nil && message.State.ActiveIssue != g.state.ActiveIssue {
-		// Voting finished or new issue dealt. Reset our vote.
+	if g.isDealer {
+		if message.State.Dealer == g.player.ID || !message.State.DealerSupersedes(g.state) {
+			return
+		}
+		if !g.dealerReplacementAllowed(&message) {
+			g.rejectDealerMessage(message.Type, errors.New("dealer role taken over while the dealer is active"))
+			return
+		}
+		err = signed.VerifySignature(message.State.DealerKey)
+		if err != nil {
+			g.rejectDealerMessage(message.Type, err)
+			return
+		}
+		err = checkStateDeck(&message.State)
+		if err != nil {
+			g.logger.Warn("state ignored as the deck is invalid", zap.Error(err))
+			return
+		}
+		// Another player took over the dealer role while we were away
+		g.logger.Info("dealer replaced", zap.Any("dealer", message.State.Dealer))
+		g.becomePlayer(&message.State)
+		g.stateTimestamp = message.Timestamp
+		return
+	}
+
+	dealerChanged := g.state != nil && message.State.Dealer != g.state.Dealer
+	if dealerChanged && !message.State.DealerSupersedes(g.state) {
+		g.logger.Warn("state ignored as published by a replaced dealer",
+			zap.Any("dealer", message.State.Dealer),
+			zap.Any("currentDealer", g.state.Dealer),
+		)
+		return
+	}
+
+	if g.state != nil && !dealerChanged && message.State.DealerTerm == g.state.DealerTerm &&
+		message.Timestamp <= g.stateTimestamp {
+		g.logger.Warn("state ignored as not newer than the current one",
+			zap.Int64("timestamp", message.Timestamp),
+			zap.Int64("stateTimestamp", g.stateTimestamp),
+		)
+		return
+	}
+
+	err = g.verifyStateSignature(&message, signed)
+	if err != nil {
+		g.rejectDealerMessage(message.Type, err)
+		return
+	}
+
+	err = checkStateDeck(&message.State)
+	if err != nil {
+		g.logger.Warn("state ignored as the deck is invalid", zap.Error(err))
+		return
+	}
+
+	if g.state != nil && newVotingRound(g.state, &message.State) {
+		// Voting finished, new issue dealt or the issue is voted again. Reset our vote.
 		g.resetMyVote()
 	}
 
+	// New dealer doesn't know votes of the current round, e.g. after a handover
+	dealerKeyChanged := g.state != nil && !bytes.Equal(g.state.DealerKey, message.State.DealerKey)
+
+	g.state = &message.State
+	g.stateTimestamp = message.Timestamp
+	g.dealerSeenAt = g.clock.Now()
+	g.notifyChangedState(false)
+
+	if dealerChanged || dealerKeyChanged {
+		g.republishVote()
+	}
+
+	g.openMyVote()
+}
+
+func (g *Game) handleDealerHandoverMessage(payload []byte) {
+	var message protocol.DealerHandoverMessage
+	signed, err := protocol.UnmarshalSignedMessage(payload, &message)
+	if err != nil {
+		g.logger.Error("failed to unmarshal message", zap.Error(err))
+		return
+	}
+
+	g.logger.Info("dealer handover message received", zap.Any("dealer", message.Dealer))
+
+	if g.state != nil && !message.State.DealerSupersedes(g.state) {
+		g.logger.Warn("dealer handover ignored as outdated")
+		return
+	}
+
+	err = g.verifyHandoverSignature(&message, signed)
+	if err != nil {
+		g.rejectDealerMessage(message.Type, err)
+		return
+	}
+
+	err = checkStateDeck(&message.State)
+	if err != nil {
+		g.logger.Warn("dealer handover ignored as the deck is invalid", zap.Error(err))
+		return
+	}
+
+	// New dealer will announce its own key with the first state, signed with its player key.
+	// Its timestamps are not comparable with the ones of the previous dealer.
+	message.State.DealerKey = nil
+	g.stateTimestamp = 0
+
+	if message.Dealer == g.player.ID {
+		g.becomeDealer(&message.State)
+		g.republishVote()
+		return
+	}
+
+	if g.isDealer {
+		g.becomePlayer(&message.State)
+		return
+	}
+
 	g.state = &message.State
-	g.state.Deck, _ = GetDeck(Fibonacci) // FIXME: remove hardcoded deck
+	g.dealerSeenAt = g.clock.Now()
 	g.notifyChangedState(false)
 }
 
+// newVotingRound returns true if the next state doesn't continue the voting round of the current one.
+// Rounds count is compared as well, in case the state with revealed votes was missed.
+func newVotingRound(current *protocol.State, next *protocol.State) bool {
+	if next.ActiveIssue != current.ActiveIssue {
+		return true
+	}
+	if current.VotesRevealed && !next.VotesRevealed {
+		return true
+	}
+	currentIssue := current.GetActiveIssue()
+	nextIssue := next.GetActiveIssue()
+	return currentIssue != nil && nextIssue != nil && currentIssue.RoundsCount() != nextIssue.RoundsCount()
+}
+
+// verifyStateSignature checks that the state is signed by the dealer key known to this player.
+// The key is pinned from the first received state. It's only allowed to change after a signed
+// handover, or when the dealer disappeared and one of the successors took over the dealer role.
+// In both cases the new key must be signed by the new dealer itself.
+func (g *Game) verifyStateSignature(message *protocol.GameStateMessage, signed *protocol.SignedMessage) error {
+	err := signed.VerifySignature(message.State.DealerKey)
+	if err != nil {
+		return err
+	}
+
+	if g.state == nil {
+		return nil
+	}
+
+	if bytes.Equal(g.state.DealerKey, message.State.DealerKey) {
+		return nil
+	}
+
+	// Dealer role was handed over, the key of the new dealer is pinned from its first state
+	if g.state.DealerKey.Empty() && message.State.Dealer == g.state.Dealer && g.dealerKeySigned(message) {
+		return nil
+	}
+
+	if !g.dealerFailoverAllowed(message) {
+		return errors.New("dealer key changed")
+	}
+
+	return nil
+}
+
+// dealerFailoverAllowed returns true if the state could be published by a player who took over the dealer role.
+// Half of the timeout is enough here to tolerate delays of the last message from the replaced dealer.
+// The new dealer key must be signed by the successor itself.
+func (g *Game) dealerFailoverAllowed(message *protocol.GameStateMessage) bool {
+	state := &message.State
+	return state.DealerTerm > g.state.DealerTerm &&
+		slices.Contains(g.state.DealerSuccessors(), state.Dealer) &&
+		g.clock.Now().Sub(g.dealerSeenAt) > g.config.DealerTimeout/2 &&
+		g.dealerKeySigned(message)
+}
+
+// dealerReplacementAllowed returns true if the state could be published by a player, who took over
+// the dealer role while this dealer was away. Players only take over when they don't receive
+// the dealer state for a while, so the state is not accepted while this dealer keeps publishing.
+func (g *Game) dealerReplacementAllowed(message *protocol.GameStateMessage) bool {
+	state := &message.State
+	_, ok := g.state.Players.Get(state.Dealer)
+	return ok && state.DealerTerm > g.state.DealerTerm &&
+		g.clock.Now().Sub(g.dealerSeenAt) > g.config.DealerTimeout/2 &&
+		g.dealerKeySigned(message)
+}
+
+// dealerKeySigned checks that the dealer key of the state is signed by the player, who is the dealer of the state
+func (g *Game) dealerKeySigned(message *protocol.GameStateMessage) bool {
+	err := protocol.VerifyDealerKey(message.State.Dealer, message.State.DealerKey, message.DealerKeySignature)
+	if err != nil {
+		g.logger.Warn("dealer key is not signed by the dealer",
+			zap.Any("dealer", message.State.Dealer),
+			zap.Error(err),
+		)
+		return false
+	}
+	return true
+}
+
+// verifyHandoverSignature checks that the handover is signed by the current dealer
+func (g *Game) verifyHandoverSignature(message *protocol.DealerHandoverMessage, signed *protocol.SignedMessage) error {
+	dealerKey := message.State.DealerKey
+	if g.state != nil && !g.state.DealerKey.Empty() {
+		dealerKey = g.state.DealerKey
+	}
+	return signed.VerifySignature(dealerKey)
+}
+
+// verifyPlayerSignature checks that the message is signed with the key, which the player ID is derived from
+func verifyPlayerSignature(playerID protocol.PlayerID, signed *protocol.SignedMessage) error {
+	key, err := playerID.PublicKey()
+	if err != nil {
+		return err
+	}
+	return signed.VerifySignature(key)
+}
+
 func (g *Game) handlePlayerOnlineMessage(payload []byte) {
 	var message protocol.PlayerOnlineMessage
-	err := json.Unmarshal(payload, &message)
+	signed, err := protocol.UnmarshalSignedMessage(payload, &message)
 	if err != nil {
 		g.logger.Error("failed to unmarshal message", zap.Error(err))
 		return
 	}
 
 	g.logger.Info("player online message received", zap.Any("player", message.Player))
+
+	err = verifyPlayerSignature(message.Player.ID, signed)
+	if err != nil {
+		g.logger.Warn("player online message ignored as not signed by the player", zap.Error(err))
+		return
+	}
+
+	if g.playerBanned(message.Player.ID) {
+		g.logger.Warn("player online message ignored as the player is banned")
+		return
+	}
+
 	message.Player.ApplyDeprecatedPatchOnReceive()
 
 	// TODO: Store player pointers in a map
 
 	index := g.playerIndex(message.Player.ID)
+	if index < 0 && g.state.Settings.JoinApproval {
+		g.knock(message.Player)
+		return
+	}
+
 	if index < 0 {
-		message.Player.Online = true
-		message.Player.OnlineTimestampMilliseconds = g.timestamp()
-		g.state.Players = append(g.state.Players, message.Player)
+		g.addPlayer(message.Player)
 		g.notifyChangedState(true)
-		g.logger.Info("player joined", zap.Any("player", message.Player))
 		return
 	}
 
+	// Players can only declare themselves spectators, it's up to the dealer to allow them voting again
+	becameSpectator := message.Player.Spectator && !g.state.Players[index].Spectator
+
 	playerChanged := !g.state.Players[index].Online ||
-		g.state.Players[index].Name != message.Player.Name
+		g.state.Players[index].Name != message.Player.Name ||
+		becameSpectator
 
 	g.state.Players[index].OnlineTimestampMilliseconds = g.timestamp()
 
@@ -61,31 +290,54 @@ func (g *Game) handlePlayerOnlineMessage(payload []byte) {
 
 	g.state.Players[index].Online = true
 	g.state.Players[index].Name = message.Player.Name
+	if becameSpectator {
+		g.state.Players[index].Spectator = true
+	}
+	g.updateAutoReveal()
 	g.notifyChangedState(true)
 }
 
 func (g *Game) handlePlayerOfflineMessage(payload []byte) {
 	var message protocol.PlayerOfflineMessage
-	err := json.Unmarshal(payload, &message)
+	signed, err := protocol.UnmarshalSignedMessage(payload, &message)
 	if err != nil {
 		g.logger.Error("failed to unmarshal message", zap.Error(err))
 		return
 	}
 
 	g.logger.Info("player is offline", zap.Any("player", message.Player))
+
+	err = verifyPlayerSignature(message.Player.ID, signed)
+	if err != nil {
+		g.logger.Warn("player offline message ignored as not signed by the player", zap.Error(err))
+		return
+	}
+
+	if g.playerBanned(message.Player.ID) {
+		g.logger.Warn("player offline message ignored as the player is banned")
+		return
+	}
+
+	if pending := g.pendingPlayerIndex(message.Player.ID); pending >= 0 {
+		// Player gave up waiting for the approval
+		g.state.PendingPlayers = slices.Delete(g.state.PendingPlayers, pending, pending+1)
+		g.notifyChangedState(true)
+		return
+	}
+
 	index := g.playerIndex(message.Player.ID)
 	if index < 0 {
 		return
 	}
 
 	g.state.Players[index].Online = false
+	g.updateAutoReveal()
 	g.notifyChangedState(true)
 }
 
 func (g *Game) handlePlayerVoteMessage(payload []byte) {
 	var message protocol.PlayerVoteMessage
-	err := json.Unmarshal(payload, &message)
-
+	signed, err := protocol.UnmarshalSignedMessage(payload, &message)
 	if err != nil {
 		g.logger.Error("failed to unmarshal message", zap.Error(err))
 		return
@@ -94,11 +346,37 @@ func (g *Game) handlePlayerVoteMessage(payload []byte) {
 	logger := g.logger.With(zap.Any("playerID", message.PlayerID))
 	logger.Info("player vote message received")
 
+	err = verifyPlayerSignature(message.PlayerID, signed)
+	if err != nil {
+		logger.Warn("player vote ignored as not signed by the player", zap.Error(err))
+		return
+	}
+
+	if g.playerBanned(message.PlayerID) {
+		logger.Warn("player vote ignored as the player is banned")
+		return
+	}
+
 	if g.state.VoteState() != protocol.VotingState {
 		g.logger.Warn("player vote ignored as not in voting state")
 		return
 	}
 
+	if g.state.Settings.JoinApproval && g.playerIndex(message.PlayerID) < 0 {
+		logger.Warn("player vote ignored as the player is not approved")
+		return
+	}
+
+	if g.state.Players.IsSpectator(message.PlayerID) {
+		logger.Warn("player vote ignored as the player is a spectator")
+		return
+	}
+
+	if g.state.Settings.CommitReveal {
+		logger.Warn("player vote ignored as commit-reveal mode is enabled")
+		return
+	}
+
 	if message.VoteResult.Value != "" && !slices.Contains(g.state.Deck, message.VoteResult.Value) {
 		logger.Warn("player vote ignored as not found in deck",
 			zap.Any("vote", message.VoteResult),
@@ -141,5 +419,242 @@ func (g *Game) handlePlayerVoteMessage(payload []byte) {
 		item.Votes[message.PlayerID] = message.VoteResult
 	}
 
+	g.updateAutoReveal()
+	g.notifyChangedState(true)
+}
+
+func (g *Game) handlePrivateMessage(payload []byte) {
+	decrypted, err := protocol.DecryptPrivateMessage(payload, g.dealerKey)
+	if err != nil {
+		g.logger.Warn("failed to decrypt private message", zap.Error(err))
+		return
+	}
+
+	message, err := protocol.UnmarshalMessage(decrypted)
+	if err != nil {
+		g.logger.Error("failed to unmarshal message", zap.Error(err))
+		return
+	}
+
+	switch message.Type {
+	case protocol.MessageTypePlayerVote:
+		g.handlePlayerVoteMessage(decrypted)
+	default:
+		g.logger.Warn("unsupported private message type", zap.String("type", string(message.Type)))
+	}
+}
+
+// handlePlayerPrivateMessage handles messages sent by the dealer to this player.
+// Private messages to other players (e.g. votes sent to the dealer) are silently skipped.
+func (g *Game) handlePlayerPrivateMessage(payload []byte) {
+	decrypted, err := protocol.DecryptPrivateMessage(payload, g.playerKey)
+	if err != nil {
+		g.logger.Debug("private message skipped as not for this player", zap.Error(err))
+		return
+	}
+
+	message, err := protocol.UnmarshalMessage(decrypted)
+	if err != nil {
+		g.logger.Error("failed to unmarshal message", zap.Error(err))
+		return
+	}
+
+	switch message.Type {
+	case protocol.MessageTypeRoomKey:
+		g.handleRoomKeyMessage(decrypted)
+	default:
+		g.logger.Warn("unsupported private message type", zap.String("type", string(message.Type)))
+	}
+}
+
+func (g *Game) handleRoomKeyMessage(payload []byte) {
+	var message protocol.RoomKeyMessage
+	signed, err := protocol.UnmarshalSignedMessage(payload, &message)
+	if err != nil {
+		g.logger.Error("failed to unmarshal message", zap.Error(err))
+		return
+	}
+
+	g.logger.Info("room key message received")
+
+	if g.state == nil {
+		g.logger.Warn("room key ignored as no state received yet")
+		return
+	}
+
+	err = signed.VerifySignature(g.state.DealerKey)
+	if err != nil {
+		g.rejectDealerMessage(message.Type, err)
+		return
+	}
+
+	if message.DealerTerm < g.roomKeyTerm ||
+		message.DealerTerm == g.roomKeyTerm && message.Timestamp <= g.roomKeyTimestamp {
+		g.logger.Warn("room key ignored as not newer than the last one",
+			zap.Int("dealerTerm", message.DealerTerm),
+			zap.Int64("timestamp", message.Timestamp),
+		)
+		return
+	}
+
+	room, err := protocol.ParseRoomID(message.RoomID)
+	if err != nil {
+		g.logger.Warn("room key ignored as the room ID is invalid", zap.Error(err))
+		return
+	}
+	if !room.VersionSupported() {
+		g.logger.Warn("room key ignored as the room version is not supported")
+		return
+	}
+	if room.ToRoomID() == g.roomID {
+		return
+	}
+
+	g.roomKeyTerm = message.DealerTerm
+	g.roomKeyTimestamp = message.Timestamp
+
+	err = g.switchRoom(room)
+	if err != nil {
+		g.logger.Error("failed to switch room", zap.Error(err))
+		return
+	}
+
+	g.notifyChangedState(false)
+}
+
+func (g *Game) handleVoteCommitMessage(payload []byte) {
+	var message protocol.PlayerVoteCommitMessage
+	signed, err := protocol.UnmarshalSignedMessage(payload, &message)
+	if err != nil {
+		g.logger.Error("failed to unmarshal message", zap.Error(err))
+		return
+	}
+
+	logger := g.logger.With(zap.Any("playerID", message.PlayerID))
+	logger.Info("player vote commitment received")
+
+	err = verifyPlayerSignature(message.PlayerID, signed)
+	if err != nil {
+		logger.Warn("vote commitment ignored as not signed by the player", zap.Error(err))
+		return
+	}
+
+	if g.playerBanned(message.PlayerID) {
+		logger.Warn("vote commitment ignored as the player is banned")
+		return
+	}
+
+	if !g.state.Settings.CommitReveal {
+		logger.Warn("vote commitment ignored as commit-reveal mode is disabled")
+		return
+	}
+
+	if g.state.VoteState() != protocol.VotingState {
+		logger.Warn("vote commitment ignored as not in voting state")
+		return
+	}
+
+	if g.state.Settings.JoinApproval && g.playerIndex(message.PlayerID) < 0 {
+		logger.Warn("vote commitment ignored as the player is not approved")
+		return
+	}
+
+	if g.state.Players.IsSpectator(message.PlayerID) {
+		logger.Warn("vote commitment ignored as the player is a spectator")
+		return
+	}
+
+	if g.state.ActiveIssue != message.Issue {
+		logger.Warn("vote commitment ignored as not for the current vote item",
+			zap.Any("voteFor", message.Issue),
+			zap.Any("currentVoteItemID", g.state.ActiveIssue),
+		)
+		return
+	}
+
+	item := g.state.Issues.Get(message.Issue)
+	if item == nil {
+		logger.Error("vote item not found", zap.Any("voteFor", message.Issue))
+		return
+	}
+
+	currentVote, voteExist := item.Votes[message.PlayerID]
+	if voteExist && currentVote.Timestamp >= message.Timestamp {
+		logger.Warn("vote commitment ignored as outdated")
+		return
+	}
+
+	if len(message.Commitment) == 0 {
+		delete(item.Votes, message.PlayerID)
+	} else {
+		item.Votes[message.PlayerID] = protocol.VoteResult{
+			Timestamp:  message.Timestamp,
+			Commitment: message.Commitment,
+		}
+	}
+
+	g.updateAutoReveal()
+	g.notifyChangedState(true)
+}
+
+func (g *Game) handleVoteOpeningMessage(payload []byte) {
+	var message protocol.PlayerVoteOpeningMessage
+	signed, err := protocol.UnmarshalSignedMessage(payload, &message)
+	if err != nil {
+		g.logger.Error("failed to unmarshal message", zap.Error(err))
+		return
+	}
+
+	logger := g.logger.With(zap.Any("playerID", message.PlayerID))
+	logger.Info("player vote opening received")
+
+	err = verifyPlayerSignature(message.PlayerID, signed)
+	if err != nil {
+		logger.Warn("vote opening ignored as not signed by the player", zap.Error(err))
+		return
+	}
+
+	if g.playerBanned(message.PlayerID) {
+		logger.Warn("vote opening ignored as the player is banned")
+		return
+	}
+
+	if g.state.VoteState() != protocol.RevealedState {
+		logger.Warn("vote opening ignored as votes are not revealed")
+		return
+	}
+
+	if g.state.ActiveIssue != message.Issue {
+		logger.Warn("vote opening ignored as not for the current vote item")
+		return
+	}
+
+	item := g.state.Issues.Get(message.Issue)
+	if item == nil {
+		logger.Error("vote item not found", zap.Any("voteFor", message.Issue))
+		return
+	}
+
+	vote, ok := item.Votes[message.PlayerID]
+	if !ok || len(vote.Commitment) == 0 {
+		logger.Warn("vote opening ignored as no commitment found")
+		return
+	}
+
+	if vote.Value != "" || vote.CommitmentMismatch {
+		logger.Debug("vote opening ignored as already processed")
+		return
+	}
+
+	if protocol.VerifyVoteCommitment(vote.Commitment, message.Vote, message.Salt) &&
+		slices.Contains(g.state.Deck, message.Vote) {
+		vote.Value = message.Vote
+	} else {
+		logger.Warn("vote opening doesn't match the commitment", zap.Any("vote", message.Vote))
+		vote.Value = message.Vote
+		vote.CommitmentMismatch = true
+	}
+
+	item.Votes[message.PlayerID] = vote
 	g.notifyChangedState(true)
 }
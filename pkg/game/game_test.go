@@ -2,19 +2,25 @@ package game
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
 	"github.com/six78/2-story-points-cli/internal/testcommon"
 	"github.com/six78/2-story-points-cli/internal/testcommon/matchers"
 	"github.com/six78/2-story-points-cli/internal/transport"
 	mocktransport "github.com/six78/2-story-points-cli/internal/transport/mock"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
+	"github.com/six78/2-story-points-cli/pkg/storage"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestGame(t *testing.T) {
@@ -91,6 +97,84 @@ func (s *Suite) expectSubscribeToMessages(room *protocol.Room) func(room *protoc
 	}
 }
 
+// newDealerRoom creates a room and joins it with the dealer.
+// Every state published by the dealer is checked to be signed with the dealer key,
+// which is signed by the dealer itself, and to keep the votes hidden until they are revealed.
+func (s *Suite) newDealerRoom() *protocol.Room {
+	room, initialState, err := s.dealer.CreateNewRoom()
+	s.Require().NoError(err)
+	s.Require().NotNil(room)
+
+	s.expectSubscribeToMessages(room)
+
+	dealerID := s.dealer.Player().ID
+	published := make(chan protocol.State, 1)
+	var mutex sync.Mutex
+	var dealerKey protocol.PublicKey
+
+	s.transport.EXPECT().PublishPublicMessage(gomock.Any(), gomock.Any()).
+		Do(func(_ *protocol.Room, payload []byte) {
+			message, err := protocol.UnmarshalMessage(payload)
+			s.Assert().NoError(err)
+			if err != nil || message.Type != protocol.MessageTypeState {
+				return
+			}
+
+			var stateMessage protocol.GameStateMessage
+			signed, err := protocol.UnmarshalSignedMessage(payload, &stateMessage)
+			s.Assert().NoError(err)
+			state := stateMessage.State
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if dealerKey == nil {
+				dealerKey = state.DealerKey
+			}
+			s.Assert().Equal(dealerKey, state.DealerKey)
+			s.Assert().NoError(signed.VerifySignature(dealerKey))
+			s.Assert().NoError(protocol.VerifyDealerKey(dealerID, dealerKey, stateMessage.DealerKeySignature))
+			s.Assert().Equal(dealerID, state.Dealer)
+
+			if issue := state.GetActiveIssue(); issue != nil && state.VoteState() == protocol.VotingState {
+				for playerID, vote := range issue.Votes {
+					s.Assert().Empty(vote.Value, "vote of %s is published before reveal", playerID)
+				}
+			}
+
+			select {
+			case published <- state:
+			default:
+			}
+		}).
+		MinTimes(1)
+
+	err = s.dealer.JoinRoom(room.ToRoomID(), initialState)
+	s.Require().NoError(err)
+
+	select {
+	case state := <-published:
+		s.Require().Equal(protocol.NewPublicKey(&s.dealer.dealerKey.PublicKey), state.DealerKey)
+	case <-time.After(time.Second):
+		s.Require().Fail("timeout waiting for the dealer state")
+	}
+
+	return room
+}
+
+func (s *Suite) newPlayerKey() (*ecdsa.PrivateKey, protocol.PlayerID) {
+	key, err := protocol.GeneratePrivateKey()
+	s.Require().NoError(err)
+	return key, protocol.NewPlayerID(&key.PublicKey)
+}
+
+func (s *Suite) signedMessage(message any, key *ecdsa.PrivateKey) []byte {
+	signed, err := protocol.NewSignedMessage(message, key)
+	s.Require().NoError(err)
+	payload, err := json.Marshal(signed)
+	s.Require().NoError(err)
+	return payload
+}
+
 func (s *Suite) TestStateSize() {
 	const playersCount = 20
 	const issuesCount = 30
@@ -107,8 +191,7 @@ func (s *Suite) TestStateSize() {
 	state.Deck = deck
 
 	for i := 0; i < playersCount; i++ {
-		playerID, err := GeneratePlayerID()
-		s.Require().NoError(err)
+		_, playerID := s.newPlayerKey()
 
 		state.Players = append(state.Players, protocol.Player{
 			ID:   playerID,
@@ -164,6 +247,7 @@ func (s *Suite) TestSimpleGame() {
 	s.Require().False(state.VotesRevealed)
 	s.Require().Empty(state.ActiveIssue)
 	s.Require().Len(state.Players, 1)
+	s.Require().False(state.DealerKey.Empty())
 	s.Logger.Info("match on join room")
 
 	// Deal first vote item
@@ -204,8 +288,10 @@ func (s *Suite) TestSimpleGame() {
 
 	{ // Publish dealer vote
 		voteMatcher := matchers.NewVoteMatcher(s.dealer.Player().ID, currentIssue.ID, dealerVote)
+		votePublished := make(chan struct{})
 		s.transport.EXPECT().
-			PublishPublicMessage(roomMatcher, voteMatcher).
+			PublishPrivateMessage(roomMatcher, voteMatcher, gomock.Eq(&s.dealer.dealerKey.PublicKey)).
+			Do(func(*protocol.Room, []byte, *ecdsa.PublicKey) { close(votePublished) }).
 			Times(1)
 
 		stateMatcher = s.newStateMatcher()
@@ -215,6 +301,7 @@ func (s *Suite) TestSimpleGame() {
 
 		err = s.dealer.PublishVote(dealerVote)
 		s.Require().NoError(err)
+		<-votePublished
 
 		state = stateMatcher.Wait()
 		item := checkIssues(state.Issues)
@@ -366,6 +453,7 @@ func (s *Suite) TestPublishMessage() {
 
 			err = game.publishMessage(payload)
 			s.Require().NoError(err)
+			game.publishing.Wait()
 		})
 	}
 }
@@ -380,8 +468,7 @@ func (s *Suite) TestOnlineState() {
 		7. Dealer checks online initialState, mark as offline
 	*/
 
-	playerID, err := GeneratePlayerID()
-	s.Require().NoError(err)
+	playerKey, playerID := s.newPlayerKey()
 
 	player := protocol.Player{
 		ID:   playerID,
@@ -423,21 +510,20 @@ func (s *Suite) TestOnlineState() {
 	_ = stateMatcher.Wait()
 
 	// Player joins the room
-	playerOnlineMessage, err := json.Marshal(&protocol.PlayerOnlineMessage{
+	playerOnlineMessage := s.signedMessage(&protocol.PlayerOnlineMessage{
 		Message: protocol.Message{
 			Type:      protocol.MessageTypePlayerOnline,
 			Timestamp: s.clock.Now().UnixMilli(),
 		},
 		Player: player,
-	})
-	s.Require().NoError(err)
+	}, playerKey)
 
 	stateMatcher = s.newStateMatcher()
 	s.transport.EXPECT().
 		PublishPublicMessage(roomMatcher, stateMatcher).
 		Times(1)
 
-	s.dealer.handlePlayerOnlineMessage(playerOnlineMessage)
+	s.dealer.handleMessage(playerOnlineMessage)
 
 	// Ensure new player joined
 	state := stateMatcher.Wait()
@@ -454,7 +540,8 @@ func (s *Suite) TestOnlineState() {
 
 	// Advance time, make sure player is marked as offline
 	lastSeenAt := p.OnlineTimestampMilliseconds
-	s.clock.Advance(playerOnlineTimeout)
+	s.clock.BlockUntil(2) // players and dealer watch loops
+	s.clock.Advance(playerOnlineTimeout + time.Second)
 
 	state = stateMatcher.Wait()
 	s.Require().Len(state.Players, 2)
@@ -464,3 +551,1925 @@ func (s *Suite) TestOnlineState() {
 	s.Require().False(p.Online)
 	s.Require().Equal(lastSeenAt, p.OnlineTimestampMilliseconds)
 }
+
+func (s *Suite) TestHandover() {
+	s.dealer = s.newGame([]Option{
+		WithPlayerName("dealer"),
+		WithEnablePublishOnlineState(false),
+	})
+
+	room, initialState, err := s.dealer.CreateNewRoom()
+	s.Require().NoError(err)
+
+	roomMatcher := matchers.NewRoomMatcher(room)
+	s.expectSubscribeToMessages(room)
+
+	stateMatcher := s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	err = s.dealer.JoinRoom(room.ToRoomID(), initialState)
+	s.Require().NoError(err)
+	_ = stateMatcher.Wait()
+
+	// Handover to unknown player fails
+	err = s.dealer.Handover(protocol.PlayerID(gofakeit.UUID()))
+	s.Require().Error(err)
+
+	// Add another player
+	playerKey, playerID := s.newPlayerKey()
+	player := protocol.Player{
+		ID:   playerID,
+		Name: gofakeit.Username(),
+	}
+	playerOnlineMessage := s.signedMessage(&protocol.PlayerOnlineMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerOnline,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		Player: player,
+	}, playerKey)
+
+	stateMatcher = s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	s.dealer.handleMessage(playerOnlineMessage)
+	_ = stateMatcher.Wait()
+
+	// Player votes for the dealt issue
+	stateMatcher = s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+	_ = stateMatcher.Wait()
+
+	stateMatcher = s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	playerVoteMessage := s.signedMessage(&protocol.PlayerVoteMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerVote,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		PlayerID:   player.ID,
+		Issue:      issueID,
+		VoteResult: *protocol.NewVoteResult("3"),
+	}, playerKey)
+
+	s.dealer.handleMessage(playerVoteMessage)
+	_ = stateMatcher.Wait()
+
+	// Dealer role is kept if the handover is not published
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, gomock.Any()).
+		Return(errors.New("publish failed")).
+		Times(1)
+
+	err = s.dealer.Handover(player.ID)
+	s.Require().Error(err)
+	s.Require().True(s.dealer.IsDealer())
+
+	// Pass the dealer role
+	handover := make(chan protocol.DealerHandoverMessage, 1)
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, gomock.Any()).
+		DoAndReturn(func(room *protocol.Room, payload []byte) error {
+			var message protocol.DealerHandoverMessage
+			signed, err := protocol.UnmarshalSignedMessage(payload, &message)
+			s.Require().NoError(err)
+			s.Require().NoError(signed.VerifySignature(protocol.NewPublicKey(&s.dealer.dealerKey.PublicKey)))
+			handover <- message
+			return nil
+		}).
+		Times(1)
+
+	err = s.dealer.Handover(player.ID)
+	s.Require().NoError(err)
+
+	message := <-handover
+	s.Require().Equal(protocol.MessageTypeDealerHandover, message.Type)
+	s.Require().Equal(player.ID, message.Dealer)
+	s.Require().Equal(player.ID, message.State.Dealer)
+	s.Require().Equal(1, message.State.DealerTerm)
+	s.Require().Len(message.State.Players, 2)
+
+	// Votes of the current round are not published
+	s.Require().Equal(issueID, message.State.ActiveIssue)
+	s.Require().Empty(message.State.Issues.Get(issueID).Votes)
+
+	s.Require().False(s.dealer.IsDealer())
+	s.Require().Equal(player.ID, s.dealer.CurrentState().Dealer)
+}
+
+func (s *Suite) TestHandoverRepublishVote() {
+	player := s.newGame([]Option{
+		WithPlayerName("player"),
+		WithEnablePublishOnlineState(false),
+	})
+
+	room, err := protocol.NewRoom()
+	s.Require().NoError(err)
+
+	roomMatcher := matchers.NewRoomMatcher(room)
+	s.expectSubscribeToMessages(room)
+
+	err = player.JoinRoom(room.ToRoomID(), nil)
+	s.Require().NoError(err)
+
+	dealerKey, err := protocol.GeneratePrivateKey()
+	s.Require().NoError(err)
+	newDealerKey, err := protocol.GeneratePrivateKey()
+	s.Require().NoError(err)
+
+	dealerID := protocol.PlayerID(gofakeit.UUID())
+	newDealerPlayerKey, newDealerID := s.newPlayerKey()
+	issueID := protocol.IssueID(gofakeit.UUID())
+	dealerState := protocol.State{
+		Players: []protocol.Player{
+			{ID: dealerID, Name: "dealer", Online: true},
+			{ID: newDealerID, Name: "new dealer", Online: true},
+			{ID: player.Player().ID, Name: "player", Online: true},
+		},
+		Issues: protocol.IssuesList{
+			{ID: issueID, TitleOrURL: gofakeit.LetterN(10), Votes: protocol.IssueVotes{}},
+		},
+		ActiveIssue: issueID,
+		Dealer:      dealerID,
+		DealerKey:   protocol.NewPublicKey(&dealerKey.PublicKey),
+	}
+	player.handleMessage(s.signedStateMessage(dealerState, dealerKey))
+	s.Require().NotNil(player.CurrentState())
+
+	// Vote is sent to the dealer
+	voteMatcher := matchers.NewVoteMatcher(player.Player().ID, issueID, "3")
+	s.transport.EXPECT().
+		PublishPrivateMessage(roomMatcher, voteMatcher, gomock.Eq(&dealerKey.PublicKey)).
+		Times(1)
+
+	err = player.PublishVote("3")
+	s.Require().NoError(err)
+	player.publishing.Wait()
+
+	// Dealer passes the role without the votes
+	handoverState := dealerState
+	handoverState.Dealer = newDealerID
+	handoverState.DealerTerm = 1
+	handover := protocol.DealerHandoverMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeDealerHandover,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		Dealer: newDealerID,
+		State:  handoverState,
+	}
+	player.handleMessage(s.signedMessage(&handover, dealerKey))
+	s.Require().Equal(newDealerID, player.CurrentState().Dealer)
+
+	// Only the new dealer can announce its key
+	attackerKey, _ := s.newPlayerKey()
+	attackerState := handoverState
+	attackerState.DealerKey = protocol.NewPublicKey(&attackerKey.PublicKey)
+	player.handleMessage(s.signedStateMessage(attackerState, attackerKey))
+	s.Require().Empty(player.CurrentState().DealerKey)
+	s.Require().Error(player.SecurityWarning())
+
+	player.handleMessage(s.signedTakeoverMessage(attackerState, attackerKey, attackerKey))
+	s.Require().Empty(player.CurrentState().DealerKey)
+
+	// Vote is sent again, once the new dealer announces its key
+	s.transport.EXPECT().
+		PublishPrivateMessage(roomMatcher, voteMatcher, gomock.Eq(&newDealerKey.PublicKey)).
+		Times(1)
+
+	newDealerState := handoverState
+	newDealerState.DealerKey = protocol.NewPublicKey(&newDealerKey.PublicKey)
+	player.handleMessage(s.signedTakeoverMessage(newDealerState, newDealerKey, newDealerPlayerKey))
+	player.publishing.Wait()
+	s.Require().Equal(newDealerState.DealerKey, player.CurrentState().DealerKey)
+}
+
+func (s *Suite) TestPlayerRevote() {
+	player := s.newGame([]Option{
+		WithPlayerName("player"),
+		WithEnablePublishOnlineState(false),
+	})
+
+	room, err := protocol.NewRoom()
+	s.Require().NoError(err)
+
+	s.expectSubscribeToMessages(room)
+	s.transport.EXPECT().
+		PublishPrivateMessage(matchers.NewRoomMatcher(room), gomock.Any(), gomock.Any()).
+		Times(2)
+
+	err = player.JoinRoom(room.ToRoomID(), nil)
+	s.Require().NoError(err)
+
+	dealerKey, dealerID := s.newPlayerKey()
+	issueID := protocol.IssueID(gofakeit.UUID())
+	issue := &protocol.Issue{ID: issueID, TitleOrURL: gofakeit.LetterN(10), Votes: protocol.IssueVotes{}}
+	dealerState := protocol.State{
+		Players: []protocol.Player{
+			{ID: dealerID, Name: "dealer", Online: true},
+			{ID: player.Player().ID, Name: "player", Online: true},
+		},
+		Issues:      protocol.IssuesList{issue},
+		ActiveIssue: issueID,
+		Dealer:      dealerID,
+		DealerKey:   protocol.NewPublicKey(&dealerKey.PublicKey),
+	}
+	publishState := func(issue protocol.Issue, revealed bool) {
+		s.clock.Advance(time.Millisecond)
+		state := dealerState
+		state.Issues = protocol.IssuesList{&issue}
+		state.VotesRevealed = revealed
+		player.handleMessage(s.signedStateMessage(state, dealerKey))
+	}
+	publishState(*issue, false)
+
+	err = player.PublishVote("3")
+	s.Require().NoError(err)
+	s.Require().Equal(protocol.VoteValue("3"), player.MyVote().Value)
+
+	// Votes are revealed, our vote is kept
+	revealed := *issue
+	revealed.Votes = protocol.IssueVotes{player.Player().ID: player.MyVote()}
+	revealed.RevealedAt = s.clock.Now().UnixMilli()
+	publishState(revealed, true)
+	s.Require().Equal(protocol.VoteValue("3"), player.MyVote().Value)
+
+	// Dealer starts a new round
+	revote := *issue
+	revote.Rounds = []protocol.VotingRound{{Votes: revealed.Votes, RevealedAt: revealed.RevealedAt}}
+	publishState(revote, false)
+	s.Require().Empty(player.MyVote().Value)
+
+	// New round is noticed, even if the revealed votes were missed
+	err = player.PublishVote("5")
+	s.Require().NoError(err)
+
+	nextRevote := revote
+	nextRevote.Rounds = append(slices.Clone(revote.Rounds), protocol.VotingRound{})
+	publishState(nextRevote, false)
+	s.Require().Empty(player.MyVote().Value)
+	player.publishing.Wait()
+}
+
+func (s *Suite) TestDealerFailover() {
+	const dealerTimeout = 10 * time.Second
+
+	player := s.newGame([]Option{
+		WithPlayerName("player"),
+		WithEnablePublishOnlineState(false),
+		WithDealerTimeout(dealerTimeout),
+	})
+
+	room, err := protocol.NewRoom()
+	s.Require().NoError(err)
+
+	roomMatcher := matchers.NewRoomMatcher(room)
+	sendMessage := s.expectSubscribeToMessages(room)
+	stateChanges := player.SubscribeToStateChanges()
+
+	err = player.JoinRoom(room.ToRoomID(), nil)
+	s.Require().NoError(err)
+	s.Require().False(player.IsDealer())
+	<-stateChanges
+
+	// Receive a state from the dealer
+	dealerKey, err := protocol.GeneratePrivateKey()
+	s.Require().NoError(err)
+
+	dealerID := protocol.PlayerID(gofakeit.UUID())
+	issueID := protocol.IssueID(gofakeit.UUID())
+	dealerState := protocol.State{
+		Players: []protocol.Player{
+			{ID: dealerID, Name: "dealer", Online: true},
+			{ID: player.Player().ID, Name: "player", Online: true},
+		},
+		Issues: protocol.IssuesList{
+			{
+				ID:         issueID,
+				TitleOrURL: gofakeit.LetterN(10),
+				Votes: protocol.IssueVotes{
+					dealerID: protocol.VoteResult{Value: "", Timestamp: 1},
+				},
+			},
+		},
+		ActiveIssue: issueID,
+		Dealer:      dealerID,
+		DealerKey:   protocol.NewPublicKey(&dealerKey.PublicKey),
+	}
+
+	sendMessage(room, s.signedStateMessage(dealerState, dealerKey))
+	state := <-stateChanges
+	s.Require().Equal(dealerID, state.Dealer)
+	s.Require().NoError(player.SecurityWarning())
+
+	// Dealer disappears, player takes over
+	stateMatcher := s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	s.clock.BlockUntil(1)
+	s.clock.Advance(dealerTimeout + time.Second)
+
+	published := stateMatcher.Wait()
+	s.Require().True(player.IsDealer())
+	s.Require().Equal(player.Player().ID, published.Dealer)
+	s.Require().Equal(1, published.DealerTerm)
+	s.Require().Equal(issueID, published.ActiveIssue)
+	s.Require().Empty(published.Issues.Get(issueID).Votes)
+
+	// Replaced dealer comes back, its state is ignored
+	player.handleMessage(s.signedStateMessage(dealerState, dealerKey))
+	s.Require().True(player.IsDealer())
+}
+
+func (s *Suite) signedStateMessage(state protocol.State, key *ecdsa.PrivateKey) []byte {
+	message := protocol.GameStateMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeState,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		State: state,
+	}
+	if key != nil {
+		return s.signedMessage(&message, key)
+	}
+	payload, err := json.Marshal(message)
+	s.Require().NoError(err)
+	return payload
+}
+
+// signedTakeoverMessage returns the state of a player, who took over the dealer role.
+// The dealer key is signed with given player key, as the new dealer does.
+func (s *Suite) signedTakeoverMessage(state protocol.State, dealerKey *ecdsa.PrivateKey, playerKey *ecdsa.PrivateKey) []byte {
+	dealerKeySignature, err := protocol.SignDealerKey(state.DealerKey, playerKey)
+	s.Require().NoError(err)
+	message := protocol.GameStateMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeState,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		State:              state,
+		DealerKeySignature: dealerKeySignature,
+	}
+	return s.signedMessage(&message, dealerKey)
+}
+
+func (s *Suite) TestForgedDealerFailover() {
+	const dealerTimeout = 10 * time.Second
+
+	player := s.newGame([]Option{
+		WithPlayerName("player"),
+		WithEnablePublishOnlineState(false),
+		WithDealerTimeout(dealerTimeout),
+	})
+
+	room, err := protocol.NewRoom()
+	s.Require().NoError(err)
+
+	s.expectSubscribeToMessages(room)
+
+	err = player.JoinRoom(room.ToRoomID(), nil)
+	s.Require().NoError(err)
+
+	dealerKey, dealerID := s.newPlayerKey()
+	successorKey, successorID := s.newPlayerKey()
+	dealerState := protocol.State{
+		Players: []protocol.Player{
+			{ID: dealerID, Name: "dealer", Online: true},
+			{ID: successorID, Name: "successor", Online: true},
+			{ID: player.Player().ID, Name: "player", Online: true},
+		},
+		Issues:    protocol.IssuesList{},
+		Dealer:    dealerID,
+		DealerKey: protocol.NewPublicKey(&dealerKey.PublicKey),
+	}
+	player.handleMessage(s.signedStateMessage(dealerState, dealerKey))
+	s.Require().Equal(dealerID, player.CurrentState().Dealer)
+
+	// Dealer disappears, but not for long enough for the player to take over
+	s.clock.BlockUntil(1)
+	s.clock.Advance(dealerTimeout/2 + time.Second)
+
+	attackerKey, attackerID := s.newPlayerKey()
+	newDealerKey, _ := s.newPlayerKey()
+	takeoverState := func(dealer protocol.PlayerID) protocol.State {
+		state := dealerState
+		state.Dealer = dealer
+		state.DealerTerm = 1
+		state.DealerKey = protocol.NewPublicKey(&newDealerKey.PublicKey)
+		return state
+	}
+
+	// Player outside of the room can't take over
+	player.handleMessage(s.signedTakeoverMessage(takeoverState(attackerID), newDealerKey, attackerKey))
+	s.Require().Equal(dealerID, player.CurrentState().Dealer)
+	s.Require().Error(player.SecurityWarning())
+
+	// Nobody can take over on behalf of the successor
+	player.handleMessage(s.signedTakeoverMessage(takeoverState(successorID), newDealerKey, attackerKey))
+	s.Require().Equal(dealerID, player.CurrentState().Dealer)
+
+	player.handleMessage(s.signedStateMessage(takeoverState(successorID), newDealerKey))
+	s.Require().Equal(dealerID, player.CurrentState().Dealer)
+
+	// Successor takes over with the dealer key signed by itself
+	player.handleMessage(s.signedTakeoverMessage(takeoverState(successorID), newDealerKey, successorKey))
+	s.Require().Equal(successorID, player.CurrentState().Dealer)
+	s.Require().Equal(protocol.NewPublicKey(&newDealerKey.PublicKey), player.CurrentState().DealerKey)
+}
+
+func (s *Suite) TestStateSignature() {
+	player := s.newGame([]Option{
+		WithPlayerName("player"),
+		WithEnablePublishOnlineState(false),
+	})
+
+	room, err := protocol.NewRoom()
+	s.Require().NoError(err)
+
+	s.expectSubscribeToMessages(room)
+
+	err = player.JoinRoom(room.ToRoomID(), nil)
+	s.Require().NoError(err)
+
+	dealerKey, err := protocol.GeneratePrivateKey()
+	s.Require().NoError(err)
+	attackerKey, err := protocol.GeneratePrivateKey()
+	s.Require().NoError(err)
+
+	dealerID := protocol.PlayerID(gofakeit.UUID())
+	dealerState := protocol.State{
+		Players: []protocol.Player{
+			{ID: dealerID, Name: "dealer", Online: true},
+			{ID: player.Player().ID, Name: "player", Online: true},
+		},
+		Issues:    protocol.IssuesList{},
+		Dealer:    dealerID,
+		DealerKey: protocol.NewPublicKey(&dealerKey.PublicKey),
+	}
+
+	// Unsigned state is rejected
+	player.handleMessage(s.signedStateMessage(dealerState, nil))
+	s.Require().Nil(player.CurrentState())
+	s.Require().ErrorIs(player.SecurityWarning(), protocol.ErrMissingSignature)
+
+	// State signed with another key than announced is rejected
+	player.handleMessage(s.signedStateMessage(dealerState, attackerKey))
+	s.Require().Nil(player.CurrentState())
+	s.Require().ErrorIs(player.SecurityWarning(), protocol.ErrUnexpectedSigner)
+
+	// Dealer key is pinned from the first state
+	firstState := s.signedStateMessage(dealerState, dealerKey)
+	player.handleMessage(firstState)
+	s.Require().NotNil(player.CurrentState())
+	s.Require().Equal(dealerState.DealerKey, player.CurrentState().DealerKey)
+
+	// State with a replaced dealer key is rejected
+	spoofedState := dealerState
+	spoofedState.Issues = protocol.IssuesList{{ID: protocol.IssueID(gofakeit.UUID())}}
+	spoofedState.DealerKey = protocol.NewPublicKey(&attackerKey.PublicKey)
+
+	player.handleMessage(s.signedStateMessage(spoofedState, attackerKey))
+	s.Require().Empty(player.CurrentState().Issues)
+	s.Require().Equal(dealerState.DealerKey, player.CurrentState().DealerKey)
+	s.Require().Error(player.SecurityWarning())
+
+	// Handover is only accepted when signed by the pinned key
+	newDealerID := dealerState.Players[1].ID
+	handoverState := dealerState
+	handoverState.Dealer = newDealerID
+	handoverState.DealerTerm = 1
+	handover := protocol.DealerHandoverMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeDealerHandover,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		Dealer: newDealerID,
+		State:  handoverState,
+	}
+	player.handleMessage(s.signedMessage(&handover, attackerKey))
+	s.Require().False(player.IsDealer())
+	s.Require().Equal(dealerID, player.CurrentState().Dealer)
+
+	// Replayed state is ignored
+	s.clock.Advance(time.Millisecond)
+	dealtState := dealerState
+	dealtState.Issues = protocol.IssuesList{{ID: protocol.IssueID(gofakeit.UUID())}}
+	player.handleMessage(s.signedStateMessage(dealtState, dealerKey))
+	s.Require().Len(player.CurrentState().Issues, 1)
+
+	player.handleMessage(firstState)
+	s.Require().Len(player.CurrentState().Issues, 1)
+}
+
+func (s *Suite) TestDealerReplacement() {
+	const dealerTimeout = 10 * time.Second
+
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+		WithDealerTimeout(dealerTimeout),
+	})
+	s.newDealerRoom()
+
+	online := func(player protocol.Player, key *ecdsa.PrivateKey) {
+		s.dealer.handleMessage(s.signedMessage(&protocol.PlayerOnlineMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerOnline,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			Player: player,
+		}, key))
+	}
+
+	// Online messages keep the dealer state unchanged while the clock goes
+	playerKey, playerID := s.newPlayerKey()
+	online(s.dealer.Player(), s.dealer.playerKey)
+	online(protocol.Player{ID: playerID, Name: gofakeit.Username()}, playerKey)
+
+	dealerState := *s.dealer.CurrentState()
+	dealerState.Players = slices.Clone(dealerState.Players)
+
+	// State of another dealer, signed with a key announced in the state itself
+	forgedKey, _ := s.newPlayerKey()
+	forgedState := func(dealer protocol.PlayerID, dealerPlayerKey *ecdsa.PrivateKey) []byte {
+		state := dealerState
+		state.Dealer = dealer
+		state.DealerTerm++
+		state.DealerKey = protocol.NewPublicKey(&forgedKey.PublicKey)
+		return s.signedTakeoverMessage(state, forgedKey, dealerPlayerKey)
+	}
+
+	// Dealer keeps the role while it's publishing the state
+	s.dealer.handleMessage(forgedState(playerID, playerKey))
+	s.Require().True(s.dealer.IsDealer())
+	s.Require().Error(s.dealer.SecurityWarning())
+
+	// Dealer routines: watch dealer, watch players and watch reveal loops
+	s.clock.BlockUntil(3)
+	s.clock.Advance(dealerTimeout/2 + time.Second)
+
+	// Only players of the room can take over
+	outsiderKey, outsiderID := s.newPlayerKey()
+	s.dealer.handleMessage(forgedState(outsiderID, outsiderKey))
+	s.Require().True(s.dealer.IsDealer())
+
+	// Nobody can take over on behalf of the player
+	s.dealer.handleMessage(forgedState(playerID, outsiderKey))
+	s.Require().True(s.dealer.IsDealer())
+
+	// Player took over while the dealer was away
+	s.dealer.handleMessage(forgedState(playerID, playerKey))
+	s.Require().False(s.dealer.IsDealer())
+	s.Require().Equal(playerID, s.dealer.CurrentState().Dealer)
+}
+
+func (s *Suite) TestCommitReveal() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	err := s.dealer.SetCommitReveal(true)
+	s.Require().NoError(err)
+
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	dealerID := s.dealer.Player().ID
+	activeIssueVotes := func() protocol.IssueVotes {
+		return s.dealer.CurrentState().Issues.Get(issueID).Votes
+	}
+
+	// Dealer votes with a commitment
+	const dealerVote = protocol.VoteValue("3")
+	err = s.dealer.PublishVote(dealerVote)
+	s.Require().NoError(err)
+
+	s.Require().Eventually(func() bool {
+		return len(activeIssueVotes()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	vote := activeIssueVotes()[dealerID]
+	s.Require().Empty(vote.Value)
+	s.Require().NotEmpty(vote.Commitment)
+
+	// Plain votes are not accepted in commit-reveal mode
+	playerKey, playerID := s.newPlayerKey()
+	payload := s.signedMessage(&protocol.PlayerVoteMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerVote,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		PlayerID:   playerID,
+		Issue:      issueID,
+		VoteResult: *protocol.NewVoteResult("5"),
+	}, playerKey)
+	s.dealer.handleMessage(payload)
+	s.Require().Len(activeIssueVotes(), 1)
+
+	// Another player commits to "5"
+	salt, err := protocol.GenerateVoteSalt()
+	s.Require().NoError(err)
+
+	payload = s.signedMessage(&protocol.PlayerVoteCommitMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeVoteCommit,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		PlayerID:   playerID,
+		Issue:      issueID,
+		Commitment: protocol.NewVoteCommitment("5", salt),
+	}, playerKey)
+	s.dealer.handleMessage(payload)
+	s.Require().Len(activeIssueVotes(), 2)
+
+	// Reveal, dealer opens own vote
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+
+	s.Require().Eventually(func() bool {
+		return activeIssueVotes()[dealerID].Value == dealerVote
+	}, time.Second, 10*time.Millisecond)
+	s.Require().False(activeIssueVotes()[dealerID].CommitmentMismatch)
+
+	// Player opens a different vote
+	payload = s.signedMessage(&protocol.PlayerVoteOpeningMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypeVoteOpening,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		PlayerID: playerID,
+		Issue:    issueID,
+		Vote:     "8",
+		Salt:     salt,
+	}, playerKey)
+	s.dealer.handleMessage(payload)
+
+	vote = activeIssueVotes()[playerID]
+	s.Require().True(vote.CommitmentMismatch)
+
+	// Mismatched vote is not taken into account
+	hint := s.dealer.CurrentState().Issues.Get(issueID).Hint
+	s.Require().NotNil(hint)
+	s.Require().Equal(dealerVote, hint.Value)
+}
+
+func (s *Suite) TestPlayerSignature() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	room, initialState, err := s.dealer.CreateNewRoom()
+	s.Require().NoError(err)
+
+	roomMatcher := matchers.NewRoomMatcher(room)
+	s.expectSubscribeToMessages(room)
+
+	stateMatcher := s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	err = s.dealer.JoinRoom(room.ToRoomID(), initialState)
+	s.Require().NoError(err)
+	_ = stateMatcher.Wait()
+
+	playerKey, playerID := s.newPlayerKey()
+	attackerKey, _ := s.newPlayerKey()
+
+	onlineMessage := func(name string) *protocol.PlayerOnlineMessage {
+		return &protocol.PlayerOnlineMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerOnline,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			Player: protocol.Player{
+				ID:   playerID,
+				Name: name,
+			},
+		}
+	}
+
+	// Unsigned message is ignored
+	payload, err := json.Marshal(onlineMessage("player"))
+	s.Require().NoError(err)
+	s.dealer.handleMessage(payload)
+	s.Require().Len(s.dealer.CurrentState().Players, 1)
+
+	// Message signed by another player is ignored
+	s.dealer.handleMessage(s.signedMessage(onlineMessage("player"), attackerKey))
+	s.Require().Len(s.dealer.CurrentState().Players, 1)
+
+	// Message signed by the player is accepted
+	stateMatcher = s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	s.dealer.handleMessage(s.signedMessage(onlineMessage("player"), playerKey))
+	state := stateMatcher.Wait()
+	s.Require().Len(state.Players, 2)
+
+	// Other players can't rename the player
+	s.dealer.handleMessage(s.signedMessage(onlineMessage("attacker"), attackerKey))
+	player, ok := s.dealer.CurrentState().Players.Get(playerID)
+	s.Require().True(ok)
+	s.Require().Equal("player", player.Name)
+}
+
+func (s *Suite) TestSpectator() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	spectatorKey, spectatorID := s.newPlayerKey()
+	voterKey, voterID := s.newPlayerKey()
+
+	onlineMessage := func(playerID protocol.PlayerID, spectator bool) *protocol.PlayerOnlineMessage {
+		return &protocol.PlayerOnlineMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerOnline,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			Player: protocol.Player{
+				ID:        playerID,
+				Name:      gofakeit.Username(),
+				Spectator: spectator,
+			},
+		}
+	}
+
+	s.dealer.handleMessage(s.signedMessage(onlineMessage(spectatorID, true), spectatorKey))
+	s.dealer.handleMessage(s.signedMessage(onlineMessage(voterID, false), voterKey))
+	s.Require().True(s.dealer.CurrentState().Players.IsSpectator(spectatorID))
+	s.Require().False(s.dealer.CurrentState().Players.IsSpectator(voterID))
+
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	voteMessage := func(playerID protocol.PlayerID) *protocol.PlayerVoteMessage {
+		return &protocol.PlayerVoteMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerVote,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			PlayerID:   playerID,
+			Issue:      issueID,
+			VoteResult: *protocol.NewVoteResult("3"),
+		}
+	}
+	activeIssueVotes := func() protocol.IssueVotes {
+		return s.dealer.CurrentState().Issues.Get(issueID).Votes
+	}
+
+	// Spectator vote is rejected
+	s.dealer.handleMessage(s.signedMessage(voteMessage(spectatorID), spectatorKey))
+	s.Require().Empty(activeIssueVotes())
+
+	s.dealer.handleMessage(s.signedMessage(voteMessage(voterID), voterKey))
+	s.Require().Len(activeIssueVotes(), 1)
+
+	// Dealer makes the voter a spectator, the vote is removed
+	err = s.dealer.SetSpectator(voterID, true)
+	s.Require().NoError(err)
+	s.Require().Empty(activeIssueVotes())
+
+	// Player can't leave the spectators by itself
+	s.clock.Advance(time.Second)
+	s.dealer.handleMessage(s.signedMessage(onlineMessage(voterID, false), voterKey))
+	s.Require().True(s.dealer.CurrentState().Players.IsSpectator(voterID))
+
+	// Dealer allows the spectator to vote
+	err = s.dealer.SetSpectator(spectatorID, false)
+	s.Require().NoError(err)
+	s.clock.Advance(time.Second)
+	s.dealer.handleMessage(s.signedMessage(voteMessage(spectatorID), spectatorKey))
+	s.Require().Contains(activeIssueVotes(), spectatorID)
+
+	// Spectators can't vote themselves
+	err = s.dealer.SetSpectator(s.dealer.Player().ID, true)
+	s.Require().NoError(err)
+	err = s.dealer.PublishVote("3")
+	s.Require().Error(err)
+
+	err = s.dealer.SetSpectator(protocol.PlayerID(gofakeit.LetterN(5)), true)
+	s.Require().Error(err)
+
+	// Spectator flag is announced in player online messages
+	spectator := s.newGame([]Option{WithSpectator(true)})
+	s.Require().True(spectator.Player().Spectator)
+}
+
+func (s *Suite) TestKickAndBan() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	playerKey, playerID := s.newPlayerKey()
+
+	onlineMessage := func() *protocol.PlayerOnlineMessage {
+		return &protocol.PlayerOnlineMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerOnline,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			Player: protocol.Player{
+				ID:   playerID,
+				Name: gofakeit.Username(),
+			},
+		}
+	}
+	playerJoined := func() bool {
+		_, ok := s.dealer.CurrentState().Players.Get(playerID)
+		return ok
+	}
+
+	s.dealer.handleMessage(s.signedMessage(onlineMessage(), playerKey))
+	s.Require().True(playerJoined())
+
+	vote := func(issueID protocol.IssueID) {
+		s.clock.Advance(time.Second)
+		s.dealer.handleMessage(s.signedMessage(&protocol.PlayerVoteMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerVote,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			PlayerID: playerID,
+			Issue:    issueID,
+			VoteResult: protocol.VoteResult{
+				Value:     "5",
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+		}, playerKey))
+	}
+
+	// Previous issue is voted twice, both rounds are revealed
+	previousIssueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+	vote(previousIssueID)
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	err = s.dealer.Revote()
+	s.Require().NoError(err)
+	vote(previousIssueID)
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	err = s.dealer.Finish("5")
+	s.Require().NoError(err)
+
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	voteMessage := &protocol.PlayerVoteMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerVote,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		PlayerID: playerID,
+		Issue:    issueID,
+		VoteResult: protocol.VoteResult{
+			Value:     "3",
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+	}
+	activeIssueVotes := func() protocol.IssueVotes {
+		return s.dealer.CurrentState().Issues.Get(issueID).Votes
+	}
+
+	s.dealer.handleMessage(s.signedMessage(voteMessage, playerKey))
+	s.Require().Contains(activeIssueVotes(), playerID)
+
+	// Kick removes the player and their vote
+	err = s.dealer.Kick(playerID)
+	s.Require().NoError(err)
+	s.Require().False(playerJoined())
+	s.Require().NotContains(activeIssueVotes(), playerID)
+
+	// Revealed votes are kept as the record of previous estimations
+	previousIssue := s.dealer.CurrentState().Issues.Get(previousIssueID)
+	s.Require().Contains(previousIssue.Votes, playerID)
+	s.Require().Len(previousIssue.Rounds, 1)
+	s.Require().Contains(previousIssue.Rounds[0].Votes, playerID)
+
+	err = s.dealer.Kick(playerID)
+	s.Require().Error(err)
+
+	// Kicked player can join again
+	s.clock.Advance(time.Second)
+	s.dealer.handleMessage(s.signedMessage(onlineMessage(), playerKey))
+	s.Require().True(playerJoined())
+
+	// Ban removes the player and ignores further messages
+	err = s.dealer.Ban(playerID)
+	s.Require().NoError(err)
+	s.Require().False(playerJoined())
+	s.Require().Equal([]protocol.PlayerID{playerID}, s.dealer.CurrentState().BannedPlayers)
+
+	err = s.dealer.Ban(playerID)
+	s.Require().Error(err)
+
+	s.clock.Advance(time.Second)
+	s.dealer.handleMessage(s.signedMessage(onlineMessage(), playerKey))
+	s.Require().False(playerJoined())
+
+	voteMessage.Timestamp = s.clock.Now().UnixMilli()
+	voteMessage.VoteResult.Timestamp = s.clock.Now().UnixMilli()
+	s.dealer.handleMessage(s.signedMessage(voteMessage, playerKey))
+	s.Require().NotContains(activeIssueVotes(), playerID)
+
+	// Dealer can't remove itself
+	err = s.dealer.Kick(s.dealer.Player().ID)
+	s.Require().Error(err)
+	err = s.dealer.Ban(s.dealer.Player().ID)
+	s.Require().Error(err)
+}
+
+func (s *Suite) TestRotateRoomKey() {
+	dealerStorage := storage.NewLocalStorage(s.T().TempDir())
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+		WithStorage(dealerStorage),
+	})
+
+	room := s.newDealerRoom()
+
+	player := s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+	s.expectSubscribeToMessages(room)
+	err := player.JoinRoom(room.ToRoomID(), nil)
+	s.Require().NoError(err)
+
+	s.dealer.handleMessage(s.signedMessage(&protocol.PlayerOnlineMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerOnline,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		Player: *player.player,
+	}, player.playerKey))
+	player.handleMessage(s.signedStateMessage(*s.dealer.hiddenCurrentState(), s.dealer.dealerKey))
+	s.Require().NotNil(player.CurrentState())
+
+	// Only dealer can rotate the key
+	err = player.RotateRoomKey()
+	s.Require().Error(err)
+
+	// Offline and banned players don't receive the key
+	_, offlineID := s.newPlayerKey()
+	_, bannedID := s.newPlayerKey()
+	s.dealer.state.Players = append(s.dealer.state.Players,
+		protocol.Player{ID: offlineID, Name: gofakeit.Username(), Online: false},
+		protocol.Player{ID: bannedID, Name: gofakeit.Username(), Online: true},
+	)
+	s.dealer.state.BannedPlayers = append(s.dealer.state.BannedPlayers, bannedID)
+
+	// New room key is sent privately to the player
+	type privateMessage struct {
+		payload   []byte
+		publicKey *ecdsa.PublicKey
+	}
+	sent := make(chan privateMessage, 1)
+	s.transport.EXPECT().
+		PublishPrivateMessage(matchers.NewRoomMatcher(room), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(room *protocol.Room, payload []byte, publicKey *ecdsa.PublicKey) error {
+			sent <- privateMessage{payload: payload, publicKey: publicKey}
+			return nil
+		}).
+		Times(1)
+	s.transport.EXPECT().
+		SubscribeToMessages(gomock.Any()).
+		Return(&transport.MessagesSubscription{Ch: make(chan []byte)}, nil).
+		Times(1)
+
+	err = s.dealer.RotateRoomKey()
+	s.Require().NoError(err)
+	s.Require().NotEqual(room.ToRoomID(), s.dealer.RoomID())
+	s.dealer.publishing.Wait()
+
+	// Old room is deleted from storage
+	_, err = dealerStorage.LoadRoomState(room.ToRoomID())
+	s.Require().Error(err)
+	_, err = dealerStorage.LoadRoomDealerKey(room.ToRoomID())
+	s.Require().Error(err)
+
+	message := <-sent
+	s.Require().Equal(protocol.NewPublicKey(&player.playerKey.PublicKey), protocol.NewPublicKey(message.publicKey))
+
+	encrypted, err := protocol.EncryptPrivateMessage(message.payload, message.publicKey)
+	s.Require().NoError(err)
+
+	// Room key signed by someone else is rejected
+	var roomKey protocol.RoomKeyMessage
+	_, err = protocol.UnmarshalSignedMessage(message.payload, &roomKey)
+	s.Require().NoError(err)
+	forgedKey, _ := s.newPlayerKey()
+	forged, err := protocol.EncryptPrivateMessage(s.signedMessage(&roomKey, forgedKey), message.publicKey)
+	s.Require().NoError(err)
+
+	player.handleMessage(forged)
+	s.Require().Equal(room.ToRoomID(), player.RoomID())
+	s.Require().Error(player.SecurityWarning())
+
+	// Player follows the dealer to the new room
+	newRoom, err := protocol.ParseRoomID(s.dealer.RoomID().String())
+	s.Require().NoError(err)
+	s.expectSubscribeToMessages(newRoom)
+
+	player.handleMessage(encrypted)
+	s.Require().Equal(s.dealer.RoomID(), player.RoomID())
+
+	// Replayed room key is rejected, even if it points to another room
+	otherRoom, err := protocol.NewRoom()
+	s.Require().NoError(err)
+	replayed := roomKey
+	replayed.RoomID = otherRoom.ToRoomID().String()
+	replayedMessage, err := protocol.EncryptPrivateMessage(s.signedMessage(&replayed, s.dealer.dealerKey), message.publicKey)
+	s.Require().NoError(err)
+
+	player.handleMessage(replayedMessage)
+	s.Require().Equal(s.dealer.RoomID(), player.RoomID())
+}
+
+func (s *Suite) TestJoinApproval() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	err := s.dealer.SetJoinApproval(true)
+	s.Require().NoError(err)
+
+	knock := func(playerID protocol.PlayerID, key *ecdsa.PrivateKey) {
+		s.dealer.handleMessage(s.signedMessage(&protocol.PlayerOnlineMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerOnline,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			Player: protocol.Player{
+				ID:   playerID,
+				Name: gofakeit.Username(),
+			},
+		}, key))
+	}
+	joined := func(playerID protocol.PlayerID) bool {
+		_, ok := s.dealer.CurrentState().Players.Get(playerID)
+		return ok
+	}
+	pending := func(playerID protocol.PlayerID) bool {
+		_, ok := s.dealer.CurrentState().PendingPlayers.Get(playerID)
+		return ok
+	}
+
+	// New player waits for approval
+	playerKey, playerID := s.newPlayerKey()
+	knock(playerID, playerKey)
+	s.Require().True(pending(playerID))
+	s.Require().False(joined(playerID))
+
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	voteMessage := func() []byte {
+		return s.signedMessage(&protocol.PlayerVoteMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerVote,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			PlayerID: playerID,
+			Issue:    issueID,
+			VoteResult: protocol.VoteResult{
+				Value:     "3",
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+		}, playerKey)
+	}
+	activeIssueVotes := func() protocol.IssueVotes {
+		return s.dealer.CurrentState().Issues.Get(issueID).Votes
+	}
+
+	// Pending player can't vote
+	s.dealer.handleMessage(voteMessage())
+	s.Require().NotContains(activeIssueVotes(), playerID)
+
+	err = s.dealer.ApprovePlayer(playerID)
+	s.Require().NoError(err)
+	s.Require().True(joined(playerID))
+	s.Require().False(pending(playerID))
+
+	s.clock.Advance(time.Second)
+	s.dealer.handleMessage(voteMessage())
+	s.Require().Contains(activeIssueVotes(), playerID)
+
+	err = s.dealer.ApprovePlayer(playerID)
+	s.Require().Error(err)
+
+	// Rejected player is not able to knock again
+	rejectedKey, rejectedID := s.newPlayerKey()
+	knock(rejectedID, rejectedKey)
+	s.Require().True(pending(rejectedID))
+
+	err = s.dealer.RejectPlayer(rejectedID)
+	s.Require().NoError(err)
+	s.Require().False(pending(rejectedID))
+
+	s.clock.Advance(time.Second)
+	knock(rejectedID, rejectedKey)
+	s.Require().False(pending(rejectedID))
+	s.Require().False(joined(rejectedID))
+
+	// Player going offline leaves the pending list
+	leavingKey, leavingID := s.newPlayerKey()
+	knock(leavingID, leavingKey)
+	s.Require().True(pending(leavingID))
+	s.dealer.handleMessage(s.signedMessage(&protocol.PlayerOfflineMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerOffline,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		Player: protocol.Player{ID: leavingID},
+	}, leavingKey))
+	s.Require().False(pending(leavingID))
+
+	// Disabling the approval lets pending players in
+	waitingKey, waitingID := s.newPlayerKey()
+	knock(waitingID, waitingKey)
+	s.Require().True(pending(waitingID))
+
+	err = s.dealer.SetJoinApproval(false)
+	s.Require().NoError(err)
+	s.Require().True(joined(waitingID))
+	s.Require().Empty(s.dealer.CurrentState().PendingPlayers)
+}
+
+func (s *Suite) TestAutoReveal() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	const delay = 5 * time.Second
+	err := s.dealer.SetAutoReveal(true, delay)
+	s.Require().NoError(err)
+
+	// Dealer only watches, so that a single player vote is enough
+	err = s.dealer.SetSpectator(s.dealer.Player().ID, true)
+	s.Require().NoError(err)
+
+	playerKey, playerID := s.newPlayerKey()
+	s.dealer.handleMessage(s.signedMessage(&protocol.PlayerOnlineMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerOnline,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		Player: protocol.Player{ID: playerID, Name: gofakeit.Username()},
+	}, playerKey))
+
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	vote := func(value protocol.VoteValue) {
+		s.clock.Advance(time.Millisecond)
+		now := s.clock.Now().UnixMilli()
+		s.dealer.handleMessage(s.signedMessage(&protocol.PlayerVoteMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerVote,
+				Timestamp: now,
+			},
+			PlayerID:   playerID,
+			Issue:      issueID,
+			VoteResult: protocol.VoteResult{Value: value, Timestamp: now},
+		}, playerKey))
+	}
+
+	// Reveal is scheduled when everyone voted
+	vote("3")
+	s.Require().Equal(s.clock.Now().Add(delay).UnixMilli(), s.dealer.CurrentState().AutoRevealAt)
+
+	// And cancelled when the vote is revoked
+	vote("")
+	s.Require().Zero(s.dealer.CurrentState().AutoRevealAt)
+
+	vote("5")
+	s.Require().NotZero(s.dealer.CurrentState().AutoRevealAt)
+
+	// Dealer routines: watch dealer, watch players and watch reveal loops
+	s.clock.BlockUntil(3)
+
+	s.clock.Advance(delay - time.Second)
+	s.Require().Never(func() bool {
+		return s.dealer.CurrentState().VotesRevealed
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	s.clock.Advance(time.Second)
+	s.Require().Eventually(func() bool {
+		return s.dealer.CurrentState().VotesRevealed
+	}, time.Second, 10*time.Millisecond)
+	s.Require().Zero(s.dealer.CurrentState().AutoRevealAt)
+}
+
+func (s *Suite) TestAutoRevealOfflinePlayer() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+	s.newDealerRoom()
+
+	err := s.dealer.SetAutoReveal(true, time.Minute)
+	s.Require().NoError(err)
+	err = s.dealer.SetSpectator(s.dealer.Player().ID, true)
+	s.Require().NoError(err)
+
+	voterKey, voterID := s.newPlayerKey()
+	pendingKey, pendingID := s.newPlayerKey()
+	voter := protocol.Player{ID: voterID, Name: gofakeit.Username()}
+	pending := protocol.Player{ID: pendingID, Name: gofakeit.Username()}
+
+	online := func(player protocol.Player, key *ecdsa.PrivateKey) {
+		s.dealer.handleMessage(s.signedMessage(&protocol.PlayerOnlineMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerOnline,
+				Timestamp: s.clock.Now().UnixMilli(),
+			},
+			Player: player,
+		}, key))
+	}
+	online(voter, voterKey)
+	online(pending, pendingKey)
+
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	now := s.clock.Now().UnixMilli()
+	s.dealer.handleMessage(s.signedMessage(&protocol.PlayerVoteMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerVote,
+			Timestamp: now,
+		},
+		PlayerID:   voterID,
+		Issue:      issueID,
+		VoteResult: protocol.VoteResult{Value: "3", Timestamp: now},
+	}, voterKey))
+	s.Require().Zero(s.dealer.CurrentState().AutoRevealAt)
+
+	// Last pending voter leaves
+	s.dealer.handleMessage(s.signedMessage(&protocol.PlayerOfflineMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerOffline,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		Player: pending,
+	}, pendingKey))
+	s.Require().NotZero(s.dealer.CurrentState().AutoRevealAt)
+
+	// And comes back before the reveal
+	online(pending, pendingKey)
+	s.Require().Zero(s.dealer.CurrentState().AutoRevealAt)
+
+	// Dealer routines: watch dealer, watch players and watch reveal loops
+	s.clock.BlockUntil(3)
+
+	// Last pending voter stops sending online messages
+	s.clock.Advance(playerOnlineTimeout / 2)
+	online(voter, voterKey)
+	s.clock.Advance(playerOnlineTimeout/2 + time.Second)
+
+	s.Require().Eventually(func() bool {
+		return s.dealer.CurrentState().AutoRevealAt != 0
+	}, time.Second, 10*time.Millisecond)
+	s.Require().False(s.dealer.CurrentState().VotesRevealed)
+}
+
+func (s *Suite) TestVotingTimer() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	// No timebox by default
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+	s.Require().Zero(s.dealer.CurrentState().Issues.Get(issueID).VotingDeadline)
+
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	err = s.dealer.Finish("1")
+	s.Require().NoError(err)
+
+	// Room default timer
+	const timer = time.Minute
+	err = s.dealer.SetVotingTimer(timer)
+	s.Require().NoError(err)
+
+	issueID, err = s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+	s.Require().Equal(s.clock.Now().Add(timer).UnixMilli(), s.dealer.CurrentState().Issues.Get(issueID).VotingDeadline)
+
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	err = s.dealer.Finish("1")
+	s.Require().NoError(err)
+
+	// Custom timer overrides the room default
+	const customTimer = 10 * time.Second
+	issueID, err = s.dealer.DealWithTimer(gofakeit.LetterN(10), customTimer)
+	s.Require().NoError(err)
+	s.Require().Equal(s.clock.Now().Add(customTimer).UnixMilli(), s.dealer.CurrentState().Issues.Get(issueID).VotingDeadline)
+
+	// Dealer routines: watch dealer, watch players and watch reveal loops
+	s.clock.BlockUntil(3)
+
+	s.clock.Advance(customTimer - time.Second)
+	s.Require().Never(func() bool {
+		return s.dealer.CurrentState().VotesRevealed
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	s.clock.Advance(time.Second)
+	s.Require().Eventually(func() bool {
+		return s.dealer.CurrentState().VotesRevealed
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = s.dealer.DealWithTimer(gofakeit.LetterN(10), -time.Second)
+	s.Require().Error(err)
+}
+
+func (s *Suite) TestFinishDealsNextIssue() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	const timer = time.Minute
+	err := s.dealer.SetVotingTimer(timer)
+	s.Require().NoError(err)
+
+	first, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+	second, err := s.dealer.AddIssue(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+
+	s.clock.Advance(time.Second)
+	err = s.dealer.Finish("1")
+	s.Require().NoError(err)
+
+	// Next issue is timeboxed as if it was dealt by the dealer
+	state := s.dealer.CurrentState()
+	s.Require().Equal(second, state.ActiveIssue)
+	s.Require().False(state.VotesRevealed)
+	s.Require().Equal(s.clock.Now().Add(timer).UnixMilli(), state.Issues.Get(second).VotingDeadline)
+	s.Require().NotNil(state.Issues.Get(first).Result)
+
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	err = s.dealer.Finish("2")
+	s.Require().NoError(err)
+
+	// Nothing else to deal
+	s.Require().Empty(s.dealer.CurrentState().ActiveIssue)
+}
+
+func (s *Suite) TestRevote() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	playerKey, playerID := s.newPlayerKey()
+	issueID, err := s.dealer.Deal(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	vote := func(value protocol.VoteValue) {
+		s.clock.Advance(time.Millisecond)
+		now := s.clock.Now().UnixMilli()
+		s.dealer.handleMessage(s.signedMessage(&protocol.PlayerVoteMessage{
+			Message: protocol.Message{
+				Type:      protocol.MessageTypePlayerVote,
+				Timestamp: now,
+			},
+			PlayerID:   playerID,
+			Issue:      issueID,
+			VoteResult: protocol.VoteResult{Value: value, Timestamp: now},
+		}, playerKey))
+	}
+	issue := func() *protocol.Issue {
+		return s.dealer.CurrentState().Issues.Get(issueID)
+	}
+
+	// Revote is only possible after reveal
+	err = s.dealer.Revote()
+	s.Require().Error(err)
+
+	vote("1")
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	revealedAt := s.clock.Now().UnixMilli()
+	s.Require().Equal(revealedAt, issue().RevealedAt)
+	s.Require().NotNil(issue().Hint)
+
+	// First round is kept in history
+	err = s.dealer.Revote()
+	s.Require().NoError(err)
+	s.Require().Equal(protocol.VotingState, s.dealer.CurrentState().VoteState())
+	s.Require().Empty(issue().Votes)
+	s.Require().Zero(issue().RevealedAt)
+	s.Require().Equal(2, issue().RoundsCount())
+
+	round := issue().Rounds[0]
+	s.Require().Equal(revealedAt, round.RevealedAt)
+	s.Require().Equal(protocol.VoteValue("1"), round.Votes[playerID].Value)
+	s.Require().NotNil(round.Hint)
+	s.Require().Equal(protocol.VoteValue("1"), round.Hint.Value)
+
+	// Second round
+	vote("3")
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	err = s.dealer.Finish("3")
+	s.Require().NoError(err)
+
+	// Dealing the issue again keeps the history as well
+	index := slices.IndexFunc(s.dealer.CurrentState().Issues, func(item *protocol.Issue) bool {
+		return item.ID == issueID
+	})
+	err = s.dealer.SelectIssue(index)
+	s.Require().NoError(err)
+	s.Require().Equal(3, issue().RoundsCount())
+	s.Require().Equal(protocol.VoteValue("3"), issue().Rounds[1].Votes[playerID].Value)
+}
+
+func (s *Suite) TestIssueManagement() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	first, err := s.dealer.AddIssue("first")
+	s.Require().NoError(err)
+	second, err := s.dealer.AddIssue("second")
+	s.Require().NoError(err)
+	third, err := s.dealer.AddIssue("third")
+	s.Require().NoError(err)
+
+	issueIDs := func() []protocol.IssueID {
+		var ids []protocol.IssueID
+		for _, issue := range s.dealer.CurrentState().Issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	// Move
+	err = s.dealer.MoveIssue(third, -1)
+	s.Require().NoError(err)
+	s.Require().Equal([]protocol.IssueID{first, third, second}, issueIDs())
+
+	err = s.dealer.MoveIssue(first, -1)
+	s.Require().Error(err)
+	err = s.dealer.MoveIssue(second, 1)
+	s.Require().Error(err)
+
+	// Rename
+	err = s.dealer.RenameIssue(second, "renamed")
+	s.Require().NoError(err)
+	s.Require().Equal("renamed", s.dealer.CurrentState().Issues.Get(second).TitleOrURL)
+
+	err = s.dealer.RenameIssue(second, "first")
+	s.Require().Error(err)
+	err = s.dealer.RenameIssue(second, "")
+	s.Require().Error(err)
+
+	// Skip the active issue
+	err = s.dealer.SelectIssue(0)
+	s.Require().NoError(err)
+	s.Require().Equal(first, s.dealer.CurrentState().ActiveIssue)
+
+	err = s.dealer.DeferIssue(first, true)
+	s.Require().NoError(err)
+	s.Require().True(s.dealer.CurrentState().Issues.Get(first).Deferred)
+	s.Require().Equal(third, s.dealer.CurrentState().ActiveIssue)
+
+	// Deferred issue is dealt last
+	finish := func() {
+		err = s.dealer.Reveal()
+		s.Require().NoError(err)
+		err = s.dealer.Finish("3")
+		s.Require().NoError(err)
+	}
+	playerKey, playerID := s.newPlayerKey()
+	s.dealer.handleMessage(s.signedMessage(&protocol.PlayerVoteMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerVote,
+			Timestamp: s.clock.Now().UnixMilli(),
+		},
+		PlayerID:   playerID,
+		Issue:      third,
+		VoteResult: protocol.VoteResult{Value: "3", Timestamp: s.clock.Now().UnixMilli()},
+	}, playerKey))
+	finish()
+	s.Require().Equal(second, s.dealer.CurrentState().ActiveIssue)
+	finish()
+	s.Require().Equal(first, s.dealer.CurrentState().ActiveIssue)
+	s.Require().False(s.dealer.CurrentState().Issues.Get(first).Deferred)
+	finish()
+	s.Require().Empty(s.dealer.CurrentState().ActiveIssue)
+
+	err = s.dealer.DeferIssue(first, true)
+	s.Require().Error(err)
+
+	// Reset result, votes are kept in the rounds history
+	err = s.dealer.ResetIssueResult(third)
+	s.Require().NoError(err)
+	reset := s.dealer.CurrentState().Issues.Get(third)
+	s.Require().Nil(reset.Result)
+	s.Require().Empty(reset.Votes)
+	s.Require().Nil(reset.Hint)
+	s.Require().Zero(reset.RevealedAt)
+	s.Require().Len(reset.Rounds, 1)
+	s.Require().Equal(protocol.VoteValue("3"), reset.Rounds[0].Votes[playerID].Value)
+	s.Require().NotZero(reset.Rounds[0].RevealedAt)
+
+	err = s.dealer.ResetIssueResult(third)
+	s.Require().Error(err)
+
+	// Remove the active issue
+	err = s.dealer.SelectIssue(1)
+	s.Require().NoError(err)
+	s.Require().Equal(third, s.dealer.CurrentState().ActiveIssue)
+
+	err = s.dealer.RemoveIssue(third)
+	s.Require().NoError(err)
+	s.Require().Equal([]protocol.IssueID{first, second}, issueIDs())
+	s.Require().Empty(s.dealer.CurrentState().ActiveIssue)
+
+	err = s.dealer.RemoveIssue(third)
+	s.Require().Error(err)
+}
+
+func (s *Suite) TestIssueMetadata() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	issueID, err := s.dealer.AddIssue(gofakeit.LetterN(10))
+	s.Require().NoError(err)
+
+	metadata := protocol.IssueMetadata{
+		Description: gofakeit.Sentence(5),
+		Labels:      []string{" bug", "", "bug ", "ui"},
+		ExternalKey: "PROJ-123",
+		Assignee:    gofakeit.Username(),
+		Notes:       gofakeit.Sentence(3),
+	}
+	err = s.dealer.SetIssueMetadata(issueID, metadata)
+	s.Require().NoError(err)
+
+	issue := s.dealer.CurrentState().Issues.Get(issueID)
+	s.Require().Equal([]string{"bug", "ui"}, issue.Labels)
+	s.Require().Equal(metadata.Description, issue.Description)
+	s.Require().Equal(metadata.ExternalKey, issue.ExternalKey)
+	s.Require().Equal(metadata.Assignee, issue.Assignee)
+	s.Require().Equal(metadata.Notes, issue.Notes)
+
+	// Metadata is transmitted in the state
+	payload, err := json.Marshal(s.dealer.hiddenCurrentState())
+	s.Require().NoError(err)
+	var received protocol.State
+	err = json.Unmarshal(payload, &received)
+	s.Require().NoError(err)
+	s.Require().Equal(issue.IssueMetadata, received.Issues.Get(issueID).IssueMetadata)
+
+	err = s.dealer.SetIssueMetadata(issueID, protocol.IssueMetadata{})
+	s.Require().NoError(err)
+	s.Require().True(s.dealer.CurrentState().Issues.Get(issueID).IssueMetadata.Empty())
+
+	err = s.dealer.SetIssueMetadata(protocol.IssueID(gofakeit.UUID()), metadata)
+	s.Require().Error(err)
+}
+
+func (s *Suite) TestCustomDeck() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	room, initialState, err := s.dealer.CreateNewRoom()
+	s.Require().NoError(err)
+
+	roomMatcher := matchers.NewRoomMatcher(room)
+	s.expectSubscribeToMessages(room)
+
+	stateMatcher := s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	err = s.dealer.JoinRoom(room.ToRoomID(), initialState)
+	s.Require().NoError(err)
+	state := stateMatcher.Wait()
+
+	defaultDeck, ok := GetDeck(DefaultDeck)
+	s.Require().True(ok)
+	s.Require().Equal(defaultDeck, state.Deck)
+
+	// Dealer sets a custom deck, it's published with the state
+	deck := protocol.Deck{"S", "M", "L"}
+
+	stateMatcher = s.newStateMatcher()
+	s.transport.EXPECT().
+		PublishPublicMessage(roomMatcher, stateMatcher).
+		Times(1)
+
+	err = s.dealer.SetDeck(deck)
+	s.Require().NoError(err)
+	state = stateMatcher.Wait()
+	s.Require().Equal(deck, state.Deck)
+
+	err = s.dealer.SetDeck(protocol.Deck{"S", "S"})
+	s.Require().Error(err)
+
+	// Player receives the deck
+	player := s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+	s.expectSubscribeToMessages(room)
+
+	err = player.JoinRoom(room.ToRoomID(), nil)
+	s.Require().NoError(err)
+
+	dealerState := *s.dealer.CurrentState()
+	player.handleMessage(s.signedStateMessage(dealerState, s.dealer.dealerKey))
+	s.Require().NotNil(player.CurrentState())
+	s.Require().Equal(deck, player.CurrentState().Deck)
+
+	// State with invalid deck is ignored
+	invalidState := dealerState
+	invalidState.Deck = protocol.Deck{"1", "1"}
+	s.clock.Advance(time.Millisecond)
+	player.handleMessage(s.signedStateMessage(invalidState, s.dealer.dealerKey))
+	s.Require().Equal(deck, player.CurrentState().Deck)
+
+	// Dealers of older versions don't send the deck
+	legacyState := dealerState
+	legacyState.Deck = nil
+	s.clock.Advance(time.Millisecond)
+	player.handleMessage(s.signedStateMessage(legacyState, s.dealer.dealerKey))
+	s.Require().Equal(defaultDeck, player.CurrentState().Deck)
+}
+
+func (s *Suite) TestHintStrategySettings() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	room := s.newDealerRoom()
+	s.Require().Equal(DefaultHintThresholds, s.dealer.HintThresholds())
+
+	err := s.dealer.SetHintStrategy(protocol.HintStrategyMajority)
+	s.Require().NoError(err)
+
+	err = s.dealer.SetHintStrategy("unknown")
+	s.Require().Error(err)
+
+	thresholds := protocol.HintThresholds{MaxDeviation: 2, MeanDeviation: 1, Majority: 0.6}
+	err = s.dealer.SetHintThresholds(thresholds)
+	s.Require().NoError(err)
+	s.Require().Equal(thresholds, s.dealer.HintThresholds())
+
+	err = s.dealer.SetHintThresholds(protocol.HintThresholds{Majority: 1})
+	s.Require().Error(err)
+
+	// Player receives the settings with the state
+	player := s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+	s.expectSubscribeToMessages(room)
+
+	err = player.JoinRoom(room.ToRoomID(), nil)
+	s.Require().NoError(err)
+
+	player.handleMessage(s.signedStateMessage(*s.dealer.CurrentState(), s.dealer.dealerKey))
+	s.Require().NotNil(player.CurrentState())
+	s.Require().Equal(protocol.HintStrategyMajority, player.CurrentState().Settings.HintStrategy)
+	s.Require().Equal(thresholds, player.HintThresholds())
+
+	// Only dealer can change the settings
+	err = player.SetHintStrategy(protocol.HintStrategyStrictConsensus)
+	s.Require().Error(err)
+}
+
+func (s *Suite) TestSavedDecks() {
+	game := s.newGame([]Option{
+		WithStorage(storage.NewLocalStorage(s.T().TempDir())),
+	})
+
+	deck := protocol.Deck{"S", "M", "L"}
+
+	err := game.SaveDeck("sizes", deck)
+	s.Require().NoError(err)
+
+	// Built-in decks can't be overwritten
+	err = game.SaveDeck(Fibonacci, deck)
+	s.Require().Error(err)
+
+	err = game.SaveDeck("invalid", protocol.Deck{"1", "1"})
+	s.Require().Error(err)
+
+	found, ok := game.FindDeck("sizes")
+	s.Require().True(ok)
+	s.Require().Equal(deck, found)
+
+	found, ok = game.FindDeck(Fibonacci)
+	s.Require().True(ok)
+	s.Require().Equal(fibonacciDeck, found)
+
+	decks, err := game.SavedDecks()
+	s.Require().NoError(err)
+	s.Require().Len(decks, 1)
+
+	err = game.DeleteDeck("sizes")
+	s.Require().NoError(err)
+
+	_, ok = game.FindDeck("sizes")
+	s.Require().False(ok)
+}
+
+func (s *Suite) TestAddIssues() {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+	})
+
+	s.newDealerRoom()
+
+	existing := gofakeit.LetterN(10)
+	_, err := s.dealer.AddIssue(existing)
+	s.Require().NoError(err)
+
+	subscription := s.dealer.SubscribeToStateChanges()
+
+	url := "https://github.com/six78/2-story-points-cli/issues/1"
+	added, err := s.dealer.AddIssues([]protocol.Issue{
+		{TitleOrURL: existing},
+		{TitleOrURL: url, IssueMetadata: protocol.IssueMetadata{
+			Description: "Title",
+			Labels:      []string{"bug", " bug "},
+		}},
+		{TitleOrURL: " "},
+		{TitleOrURL: url},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(added, 1)
+
+	// All issues are added in a single state update
+	s.Require().Len(subscription, 1)
+
+	issues := s.dealer.CurrentState().Issues
+	s.Require().Len(issues, 2)
+	issue := issues.Get(added[0])
+	s.Require().NotNil(issue)
+	s.Require().Equal(url, issue.TitleOrURL)
+	s.Require().Equal("Title", issue.Description)
+	s.Require().Equal([]string{"bug"}, issue.Labels)
+	s.Require().NotNil(issue.Votes)
+
+	// Nothing new to add
+	added, err = s.dealer.AddIssues([]protocol.Issue{{TitleOrURL: url}})
+	s.Require().NoError(err)
+	s.Require().Empty(added)
+	s.Require().Len(subscription, 1)
+}
+
+type issueSyncCall struct {
+	deck  protocol.Deck
+	issue protocol.Issue
+}
+
+type fakeIssueSync chan issueSyncCall
+
+func (f fakeIssueSync) SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error {
+	f <- issueSyncCall{deck: deck, issue: issue}
+	return errors.New("sync failed")
+}
+
+func (s *Suite) TestIssueSync() {
+	issueSync := make(fakeIssueSync, 1)
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+		WithIssueSync(issueSync),
+	})
+
+	s.newDealerRoom()
+
+	playerKey, playerID := s.newPlayerKey()
+	url := "https://github.com/six78/2-story-points-cli/issues/1"
+	issueID, err := s.dealer.Deal(url)
+	s.Require().NoError(err)
+
+	now := s.clock.Now().UnixMilli()
+	s.dealer.handleMessage(s.signedMessage(&protocol.PlayerVoteMessage{
+		Message: protocol.Message{
+			Type:      protocol.MessageTypePlayerVote,
+			Timestamp: now,
+		},
+		PlayerID:   playerID,
+		Issue:      issueID,
+		VoteResult: protocol.VoteResult{Value: "3", Timestamp: now},
+	}, playerKey))
+
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	s.Require().Empty(issueSync)
+
+	// Sync failure doesn't affect the game
+	err = s.dealer.Finish("5")
+	s.Require().NoError(err)
+	s.Require().NotNil(s.dealer.CurrentState().Issues.Get(issueID).Result)
+
+	select {
+	case call := <-issueSync:
+		s.Require().Equal(s.dealer.CurrentState().Deck, call.deck)
+		s.Require().Equal(url, call.issue.TitleOrURL)
+		s.Require().Equal(protocol.VoteValue("5"), *call.issue.Result)
+		s.Require().Equal(protocol.VoteValue("3"), call.issue.Votes[playerID].Value)
+	case <-time.After(time.Second):
+		s.Require().Fail("issue sync was not called")
+	}
+}
+
+// stopWithIssueSync finishes an issue with a blocking issue sync
+// and stops the game in background. Returns a function to check if it's stopped.
+func (s *Suite) stopWithIssueSync(issueSync fakeIssueSync) func() bool {
+	s.dealer = s.newGame([]Option{
+		WithEnablePublishOnlineState(false),
+		WithIssueSync(issueSync),
+	})
+	s.newDealerRoom()
+
+	_, err := s.dealer.Deal("1")
+	s.Require().NoError(err)
+	err = s.dealer.Reveal()
+	s.Require().NoError(err)
+	err = s.dealer.Finish("1")
+	s.Require().NoError(err)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.dealer.Stop()
+		close(stopped)
+	}()
+
+	return func() bool {
+		select {
+		case <-stopped:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+func (s *Suite) TestStopWaitsIssueSync() {
+	// Unbuffered sync blocks until the call is received
+	issueSync := make(fakeIssueSync)
+	stopped := s.stopWithIssueSync(issueSync)
+	s.Require().Never(stopped, 100*time.Millisecond, 10*time.Millisecond)
+
+	<-issueSync
+	s.Require().Eventually(stopped, time.Second, 10*time.Millisecond)
+}
+
+func (s *Suite) TestStopIssueSyncTimeout() {
+	issueSync := make(fakeIssueSync)
+	stopped := s.stopWithIssueSync(issueSync)
+	s.Require().Eventually(func() bool {
+		s.clock.Advance(issueSyncTimeout)
+		return stopped()
+	}, time.Second, 10*time.Millisecond)
+
+	<-issueSync
+}
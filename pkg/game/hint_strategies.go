@@ -0,0 +1,172 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+// HintVotes are the numeric votes of an issue, prepared for a HintStrategy
+type HintVotes struct {
+	Deck protocol.Deck
+
+	// Indexes are the deck indexes of the votes.
+	// In protocol.HintModeNumeric these are the indexes of the nearest cards.
+	Indexes []int
+
+	// Numbers are the votes in real units.
+	// Only present in protocol.HintModeNumeric.
+	Numbers []float64
+}
+
+// HintStrategy calculates the hint from the votes.
+// Votes are guaranteed to be non-empty.
+type HintStrategy interface {
+	Hint(votes HintVotes) *protocol.Hint
+}
+
+// NewHintStrategy creates a strategy by its name.
+// Empty name stands for protocol.HintStrategyMedianDeviation.
+func NewHintStrategy(name protocol.HintStrategyName, thresholds protocol.HintThresholds) (HintStrategy, error) {
+	switch name {
+	case "", protocol.HintStrategyMedianDeviation:
+		return &medianDeviationStrategy{thresholds: thresholds}, nil
+	case protocol.HintStrategyStrictConsensus:
+		return &strictConsensusStrategy{}, nil
+	case protocol.HintStrategyMajority:
+		return &majorityStrategy{thresholds: thresholds}, nil
+	case protocol.HintStrategyMeanRoundedUp:
+		return &meanRoundedUpStrategy{thresholds: thresholds}, nil
+	}
+	return nil, fmt.Errorf("unknown hint strategy: '%s', available strategies: %s",
+		name, strings.Join(hintStrategiesNames(), ", "))
+}
+
+func AvailableHintStrategies() []protocol.HintStrategyName {
+	return []protocol.HintStrategyName{
+		protocol.HintStrategyMedianDeviation,
+		protocol.HintStrategyStrictConsensus,
+		protocol.HintStrategyMajority,
+		protocol.HintStrategyMeanRoundedUp,
+	}
+}
+
+func hintStrategiesNames() []string {
+	strategies := AvailableHintStrategies()
+	names := make([]string, len(strategies))
+	for i, strategy := range strategies {
+		names[i] = string(strategy)
+	}
+	return names
+}
+
+// medianDeviationStrategy recommends the median vote.
+// Votes are not acceptable when they deviate from the median too much.
+type medianDeviationStrategy struct {
+	thresholds protocol.HintThresholds
+}
+
+func (s *medianDeviationStrategy) Hint(votes HintVotes) *protocol.Hint {
+	measures := getMeasures(votes.Indexes)
+	if votes.Numbers != nil {
+		medianIndex := nearestCardIndex(votes.Deck, numericMedian(sortedNumbers(votes.Numbers)))
+		measures = getDeviations(hintMeasurements{median: medianIndex}, votes.Indexes)
+	}
+
+	hint := &protocol.Hint{
+		Value:      votes.Deck[measures.median],
+		Advice:     "",
+		Acceptable: true,
+	}
+
+	applyAcceptance(hint, measures, s.thresholds)
+
+	return hint
+}
+
+// strictConsensusStrategy only accepts the votes when all of them are the same card.
+// Otherwise, the median vote is recommended for the discussion.
+type strictConsensusStrategy struct{}
+
+func (s *strictConsensusStrategy) Hint(votes HintVotes) *protocol.Hint {
+	measures := getMeasures(votes.Indexes)
+
+	hint := &protocol.Hint{
+		Value:      votes.Deck[measures.median],
+		Advice:     "",
+		Acceptable: true,
+	}
+
+	if measures.maxDeviation > 0 {
+		hint.Acceptable = false
+		hint.RejectReason = votesAreNotUnanimous
+	}
+
+	return hint
+}
+
+// majorityStrategy recommends the most popular vote.
+// When several votes are equally popular, the higher one is chosen.
+type majorityStrategy struct {
+	thresholds protocol.HintThresholds
+}
+
+func (s *majorityStrategy) Hint(votes HintVotes) *protocol.Hint {
+	counts := make(map[int]int, len(votes.Indexes))
+	for _, index := range votes.Indexes {
+		counts[index]++
+	}
+
+	popular := -1
+	for index, count := range counts {
+		if popular < 0 || count > counts[popular] || (count == counts[popular] && index > popular) {
+			popular = index
+		}
+	}
+
+	hint := &protocol.Hint{
+		Value:      votes.Deck[popular],
+		Advice:     "",
+		Acceptable: true,
+	}
+
+	share := float64(counts[popular]) / float64(len(votes.Indexes))
+	if share <= s.thresholds.Majority {
+		hint.Acceptable = false
+		hint.RejectReason = noMajorityVote
+	}
+
+	return hint
+}
+
+// meanRoundedUpStrategy recommends the mean vote, rounded up to the next card.
+// Votes are not acceptable when they deviate from the recommended value too much.
+type meanRoundedUpStrategy struct {
+	thresholds protocol.HintThresholds
+}
+
+func (s *meanRoundedUpStrategy) Hint(votes HintVotes) *protocol.Hint {
+	var valueIndex int
+	if votes.Numbers != nil {
+		valueIndex = ceilCardIndex(votes.Deck, mean(votes.Numbers))
+	} else {
+		indexes := make([]float64, len(votes.Indexes))
+		for i, index := range votes.Indexes {
+			indexes[i] = float64(index)
+		}
+		valueIndex = int(math.Ceil(mean(indexes)))
+	}
+
+	hint := &protocol.Hint{
+		Value:      votes.Deck[valueIndex],
+		Advice:     "",
+		Acceptable: true,
+	}
+
+	measures := getDeviations(hintMeasurements{median: valueIndex}, votes.Indexes)
+	applyAcceptance(hint, measures, s.thresholds)
+
+	return hint
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"fmt"
 	"math"
 	"sort"
 
@@ -28,12 +29,53 @@ const (
 	// Rejection reasons
 	varietyOfVotesIsTooHigh   = "Variety of votes is too high"
 	maximumDeviationIsTooHigh = "Maximum deviation is too high"
+	votesAreNotUnanimous      = "Votes are not unanimous"
+	noMajorityVote            = "No vote has the majority"
 )
 
 var (
 	ErrVoteNotFoundInDeck = errors.New("vote not found in deck")
+	ErrNoNumericVotes     = errors.New("no numeric votes")
+	ErrNoNumericCards     = errors.New("no numeric cards in deck")
 )
 
+var DefaultHintThresholds = protocol.HintThresholds{
+	MaxDeviation:  maxAcceptableMaximumDeviation,
+	MeanDeviation: maxAcceptableMeanDeviation,
+	Majority:      0.5,
+}
+
+// RoomHintThresholds returns the thresholds of the room, or the default ones if not set
+func RoomHintThresholds(settings protocol.RoomSettings) protocol.HintThresholds {
+	if settings.HintThresholds == nil {
+		return DefaultHintThresholds
+	}
+	return *settings.HintThresholds
+}
+
+// GetRoomHint calculates the hint with the strategy, thresholds and mode of the room
+func GetRoomHint(settings protocol.RoomSettings, deck protocol.Deck, issueVotes protocol.IssueVotes) (*protocol.Hint, error) {
+	strategy, err := NewHintStrategy(settings.HintStrategy, RoomHintThresholds(settings))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create hint strategy")
+	}
+	return GetHint(strategy, settings.HintMode, deck, issueVotes)
+}
+
+// CountedVotes skips votes that are not opened yet or don't match the commitment,
+// and votes of players, who became spectators after voting
+func CountedVotes(state *protocol.State, issue *protocol.Issue) protocol.IssueVotes {
+	votes := make(protocol.IssueVotes, len(issue.Votes))
+	for playerID, vote := range issue.Votes {
+		if vote.Counted() && !state.Players.IsSpectator(playerID) {
+			votes[playerID] = vote
+		}
+	}
+	return votes
+}
+
+// GetResultHint calculates the hint with the default strategy.
+// All votes must be present in the deck.
 func GetResultHint(deck protocol.Deck, issueVotes protocol.IssueVotes) (*protocol.Hint, error) {
 	// Get votes as deck indexes.
 	// We ignore the actual deck values when calculating the hint.
@@ -42,29 +84,188 @@ func GetResultHint(deck protocol.Deck, issueVotes protocol.IssueVotes) (*protoco
 		return nil, err
 	}
 
-	// Calculate measures for the votes
-	resultMeasures := getMeasures(indexes)
-	medianValueIndex := resultMeasures.median
-	medianValue := deck[medianValueIndex]
+	strategy := &medianDeviationStrategy{thresholds: DefaultHintThresholds}
+	hint := strategy.Hint(HintVotes{Deck: deck, Indexes: indexes})
+
+	outliers := voteOutliers{}
+	for playerID, vote := range issueVotes {
+		outliers.add(playerID, float64(deck.Index(vote.Value)))
+	}
+	fillAdvice(hint, outliers)
+
+	return hint, nil
+}
+
+// GetHint prepares the votes according to the hint mode and passes them to the strategy.
+// Votes with special cards, like "?" or "☕", are counted as abstained.
+// In protocol.HintModeNumeric votes don't have to be present in the deck,
+// but the recommended value is always a card from the deck.
+func GetHint(strategy HintStrategy, mode protocol.HintMode, deck protocol.Deck, issueVotes protocol.IssueVotes) (*protocol.Hint, error) {
+	votes := HintVotes{
+		Deck:    deck,
+		Indexes: make([]int, 0, len(issueVotes)),
+	}
+	abstained := 0
+	outliers := voteOutliers{}
+
+	for playerID, vote := range issueVotes {
+		if mode != protocol.HintModeNumeric {
+			if vote.Value.Special() {
+				abstained++
+				continue
+			}
+			index := deck.Index(vote.Value)
+			if index < 0 {
+				return nil, ErrVoteNotFoundInDeck
+			}
+			votes.Indexes = append(votes.Indexes, index)
+			outliers.add(playerID, float64(index))
+			continue
+		}
 
-	// Build the hint based on the measures
-	hint := &protocol.Hint{
-		Value:      medianValue,
-		Advice:     "",
-		Acceptable: true,
+		number, ok := vote.Value.Number()
+		if !ok {
+			abstained++
+			continue
+		}
+		index := nearestCardIndex(deck, number)
+		if index < 0 {
+			return nil, ErrNoNumericCards
+		}
+		votes.Indexes = append(votes.Indexes, index)
+		votes.Numbers = append(votes.Numbers, number)
+		outliers.add(playerID, number)
 	}
 
-	if resultMeasures.maxDeviation > maxAcceptableMaximumDeviation {
+	if len(votes.Indexes) == 0 {
+		return nil, ErrNoNumericVotes
+	}
+
+	hint := strategy.Hint(votes)
+	hint.Abstained = abstained
+	fillAdvice(hint, outliers)
+	if mode == protocol.HintModeNumeric {
+		hint.Stats = getNumericStats(votes.Numbers)
+	}
+
+	return hint, nil
+}
+
+// voteOutliers tracks the players with the lowest and the highest votes.
+// When several players have the same vote, the lowest player ID is taken,
+// so that all clients produce the same advice.
+type voteOutliers struct {
+	lowest       protocol.PlayerID
+	highest      protocol.PlayerID
+	lowestValue  float64
+	highestValue float64
+}
+
+func (o *voteOutliers) add(playerID protocol.PlayerID, value float64) {
+	if o.lowest == "" || value < o.lowestValue || (value == o.lowestValue && playerID < o.lowest) {
+		o.lowest = playerID
+		o.lowestValue = value
+	}
+	if o.highest == "" || value > o.highestValue || (value == o.highestValue && playerID < o.highest) {
+		o.highest = playerID
+		o.highestValue = value
+	}
+}
+
+// fillAdvice asks the players with the lowest and the highest votes to explain their estimates.
+// There's nothing to discuss when the votes are acceptable or all the same.
+func fillAdvice(hint *protocol.Hint, outliers voteOutliers) {
+	if hint.Acceptable || outliers.lowestValue == outliers.highestValue {
+		return
+	}
+	hint.Advice = fmt.Sprintf("%s and %s, explain your estimates",
+		protocol.Mention(outliers.lowest), protocol.Mention(outliers.highest))
+}
+
+func applyAcceptance(hint *protocol.Hint, measures hintMeasurements, thresholds protocol.HintThresholds) {
+	if measures.maxDeviation > thresholds.MaxDeviation {
 		hint.Acceptable = false
 		hint.RejectReason = maximumDeviationIsTooHigh
 	}
 
-	if resultMeasures.meanDeviation >= maxAcceptableMeanDeviation {
+	if measures.meanDeviation >= thresholds.MeanDeviation {
 		hint.Acceptable = false
 		hint.RejectReason = varietyOfVotesIsTooHigh
 	}
+}
 
-	return hint, nil
+func getNumericStats(values []float64) *protocol.HintStats {
+	sorted := sortedNumbers(values)
+
+	return &protocol.HintStats{
+		Mean:   mean(sorted),
+		Median: numericMedian(sorted),
+		Spread: sorted[len(sorted)-1] - sorted[0],
+	}
+}
+
+// sortedNumbers returns a sorted copy, so that the votes order is kept
+func sortedNumbers(values []float64) []float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// numericMedian expects sorted values.
+// It averages two central values when the number of values is even.
+func numericMedian(sorted []float64) float64 {
+	center := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[center-1] + sorted[center]) / 2
+	}
+	return sorted[center]
+}
+
+// nearestCardIndex returns the index of a numeric card, which is the nearest to given value.
+// When value is exactly between two cards, the higher card is chosen.
+func nearestCardIndex(deck protocol.Deck, value float64) int {
+	result := -1
+	minDistance := math.Inf(1)
+	for i, card := range deck {
+		number, ok := card.Number()
+		if !ok {
+			continue
+		}
+		distance := math.Abs(number - value)
+		if distance < minDistance || (distance == minDistance && number > value) {
+			minDistance = distance
+			result = i
+		}
+	}
+	return result
+}
+
+// ceilCardIndex returns the index of the lowest numeric card, which is not less than given value.
+// When value is higher than any card, the highest numeric card is returned.
+func ceilCardIndex(deck protocol.Deck, value float64) int {
+	result := -1
+	resultNumber := math.Inf(-1)
+	for i, card := range deck {
+		number, ok := card.Number()
+		if !ok {
+			continue
+		}
+		if result < 0 ||
+			(resultNumber < value && number > resultNumber) ||
+			(number >= value && number < resultNumber) {
+			result = i
+			resultNumber = number
+		}
+	}
+	return result
 }
 
 func getVotesAsDeckIndexes(issueVotes protocol.IssueVotes, deck protocol.Deck) ([]int, error) {
@@ -90,6 +291,11 @@ func getMeasures(values []int) hintMeasurements {
 	// median value
 	r.median = median(values)
 
+	return getDeviations(r, values)
+}
+
+// getDeviations calculates deviations of values around the median
+func getDeviations(r hintMeasurements, values []int) hintMeasurements {
 	// Maximum deviation
 	r.maxDeviation = 0
 	for _, v := range values {
@@ -105,7 +311,6 @@ func getMeasures(values []int) hintMeasurements {
 	r.meanDeviation = float64(sum) / float64(len(values))
 
 	return r
-
 }
 
 func median(values []int) int {
@@ -124,6 +124,10 @@ func TestHint(t *testing.T) {
 			// Now check the actual hint (public API)
 			hint, err := GetResultHint(deck, issueVotes)
 			require.NoError(t, err)
+
+			// Advice mentions random player IDs, it's checked in TestHintAdvice
+			require.Equal(t, tc.expectedHint.Acceptable, hint.Advice == "")
+			hint.Advice = ""
 			require.Equal(t, tc.expectedHint, *hint)
 		})
 	}
@@ -137,6 +141,143 @@ func TestInvalidVote(t *testing.T) {
 	require.Equal(t, ErrVoteNotFoundInDeck, err)
 }
 
+func TestHintStrategies(t *testing.T) {
+	deck := protocol.Deck{"1", "2", "3", "5", "8", "13", "?"}
+
+	testCases := []struct {
+		strategy     protocol.HintStrategyName
+		thresholds   protocol.HintThresholds
+		values       []protocol.VoteValue
+		expectedHint protocol.Hint
+	}{
+		{
+			strategy:     protocol.HintStrategyMedianDeviation,
+			thresholds:   DefaultHintThresholds,
+			values:       []protocol.VoteValue{"3", "3", "5"},
+			expectedHint: protocol.Hint{Acceptable: true, Value: "3"},
+		},
+		{
+			strategy:     protocol.HintStrategyMedianDeviation,
+			thresholds:   protocol.HintThresholds{MaxDeviation: 0, MeanDeviation: 1},
+			values:       []protocol.VoteValue{"3", "3", "5"},
+			expectedHint: protocol.Hint{Acceptable: false, Value: "3", RejectReason: maximumDeviationIsTooHigh},
+		},
+		{
+			strategy:     protocol.HintStrategyStrictConsensus,
+			values:       []protocol.VoteValue{"5", "5", "5"},
+			expectedHint: protocol.Hint{Acceptable: true, Value: "5"},
+		},
+		{
+			strategy:     protocol.HintStrategyStrictConsensus,
+			values:       []protocol.VoteValue{"3", "5", "5"},
+			expectedHint: protocol.Hint{Acceptable: false, Value: "5", RejectReason: votesAreNotUnanimous},
+		},
+		{
+			strategy:     protocol.HintStrategyMajority,
+			thresholds:   DefaultHintThresholds,
+			values:       []protocol.VoteValue{"1", "8", "8"},
+			expectedHint: protocol.Hint{Acceptable: true, Value: "8"},
+		},
+		{
+			// Equally popular votes are rounded up
+			strategy:     protocol.HintStrategyMajority,
+			thresholds:   DefaultHintThresholds,
+			values:       []protocol.VoteValue{"2", "2", "3", "3"},
+			expectedHint: protocol.Hint{Acceptable: false, Value: "3", RejectReason: noMajorityVote},
+		},
+		{
+			strategy:     protocol.HintStrategyMajority,
+			thresholds:   protocol.HintThresholds{Majority: 0.7},
+			values:       []protocol.VoteValue{"1", "8", "8"},
+			expectedHint: protocol.Hint{Acceptable: false, Value: "8", RejectReason: noMajorityVote},
+		},
+		{
+			strategy:     protocol.HintStrategyMeanRoundedUp,
+			thresholds:   DefaultHintThresholds,
+			values:       []protocol.VoteValue{"2", "3", "3", "3"},
+			expectedHint: protocol.Hint{Acceptable: true, Value: "3"},
+		},
+		{
+			strategy:     protocol.HintStrategyMeanRoundedUp,
+			thresholds:   DefaultHintThresholds,
+			values:       []protocol.VoteValue{"2", "3", "5", "8"},
+			expectedHint: protocol.Hint{Acceptable: false, Value: "5", RejectReason: varietyOfVotesIsTooHigh},
+		},
+	}
+
+	for _, tc := range testCases {
+		name := string(tc.strategy) + "/" + voteValuesString(tc.values)
+		t.Run(name, func(t *testing.T) {
+			strategy, err := NewHintStrategy(tc.strategy, tc.thresholds)
+			require.NoError(t, err)
+
+			hint, err := GetHint(strategy, protocol.HintModeIndex, deck, buildIssueVotes(tc.values))
+			require.NoError(t, err)
+			hint.Advice = ""
+			require.Equal(t, tc.expectedHint, *hint)
+		})
+	}
+
+	_, err := NewHintStrategy("unknown", DefaultHintThresholds)
+	require.Error(t, err)
+}
+
+func TestMeanRoundedUpNumeric(t *testing.T) {
+	deck := protocol.Deck{"1", "2", "3", "5", "8", "13", "?"}
+	strategy, err := NewHintStrategy(protocol.HintStrategyMeanRoundedUp, DefaultHintThresholds)
+	require.NoError(t, err)
+
+	// Mean is 3.25, rounded up to the next card
+	issueVotes := buildIssueVotes([]protocol.VoteValue{"2", "3", "3", "5"})
+	hint, err := GetHint(strategy, protocol.HintModeNumeric, deck, issueVotes)
+	require.NoError(t, err)
+	require.Equal(t, protocol.VoteValue("5"), hint.Value)
+
+	// Mean higher than any card is rounded down to the highest card
+	issueVotes = buildIssueVotes([]protocol.VoteValue{"20", "20"})
+	hint, err = GetHint(strategy, protocol.HintModeNumeric, deck, issueVotes)
+	require.NoError(t, err)
+	require.Equal(t, protocol.VoteValue("13"), hint.Value)
+}
+
+func TestHintAdvice(t *testing.T) {
+	deck := protocol.Deck{"1", "2", "3", "5", "8", "13", "?"}
+
+	issueVotes := protocol.IssueVotes{
+		"alice": protocol.VoteResult{Value: "1"},
+		"bob":   protocol.VoteResult{Value: "13"},
+		"carol": protocol.VoteResult{Value: "3"},
+		"dave":  protocol.VoteResult{Value: "1"},
+		"eve":   protocol.VoteResult{Value: "?"},
+	}
+
+	hint, err := GetResultHint(deck, protocol.IssueVotes{
+		"alice": issueVotes["alice"],
+		"bob":   issueVotes["bob"],
+		"carol": issueVotes["carol"],
+	})
+	require.NoError(t, err)
+	require.False(t, hint.Acceptable)
+	require.Equal(t, "@alice and @bob, explain your estimates", hint.Advice)
+
+	// Special cards are not mentioned, same votes are resolved by player ID
+	strategy, err := NewHintStrategy(protocol.HintStrategyMedianDeviation, DefaultHintThresholds)
+	require.NoError(t, err)
+	hint, err = GetHint(strategy, protocol.HintModeNumeric, deck, issueVotes)
+	require.NoError(t, err)
+	require.False(t, hint.Acceptable)
+	require.Equal(t, "@alice and @bob, explain your estimates", hint.Advice)
+
+	// No advice for acceptable votes
+	hint, err = GetResultHint(deck, protocol.IssueVotes{
+		"alice": protocol.VoteResult{Value: "3"},
+		"bob":   protocol.VoteResult{Value: "3"},
+	})
+	require.NoError(t, err)
+	require.True(t, hint.Acceptable)
+	require.Empty(t, hint.Advice)
+}
+
 func voteValuesString(values []protocol.VoteValue) string {
 	list := make([]string, len(values))
 	for i, v := range values {
@@ -153,3 +294,51 @@ func buildIssueVotes(votes []protocol.VoteValue) protocol.IssueVotes {
 	}
 	return issueVotes
 }
+
+func TestNumericHint(t *testing.T) {
+	defaultStrategy, err := NewHintStrategy("", DefaultHintThresholds)
+	require.NoError(t, err)
+	deck := protocol.Deck{"1h", "2h", "4h", "8h", "16h", "?"}
+
+	issueVotes := buildIssueVotes([]protocol.VoteValue{"4h", "4h", "4h", "8h", "?"})
+	hint, err := GetHint(defaultStrategy, protocol.HintModeNumeric, deck, issueVotes)
+	require.NoError(t, err)
+	require.Equal(t, protocol.VoteValue("4h"), hint.Value)
+	require.True(t, hint.Acceptable)
+	require.Equal(t, 1, hint.Abstained)
+	require.Equal(t, &protocol.HintStats{Mean: 5, Median: 4, Spread: 4}, hint.Stats)
+
+	// Median between cards is rounded to the nearest card
+	issueVotes = buildIssueVotes([]protocol.VoteValue{"2h", "4h", "8h", "16h"})
+	hint, err = GetHint(defaultStrategy, protocol.HintModeNumeric, deck, issueVotes)
+	require.NoError(t, err)
+	require.Equal(t, protocol.VoteValue("8h"), hint.Value)
+	require.False(t, hint.Acceptable)
+	require.Equal(t, 0, hint.Abstained)
+	require.Equal(t, 6.0, hint.Stats.Median)
+
+	issueVotes = buildIssueVotes([]protocol.VoteValue{"?", "?"})
+	_, err = GetHint(defaultStrategy, protocol.HintModeNumeric, deck, issueVotes)
+	require.ErrorIs(t, err, ErrNoNumericVotes)
+
+	issueVotes = buildIssueVotes([]protocol.VoteValue{"1"})
+	_, err = GetHint(defaultStrategy, protocol.HintModeNumeric, protocol.Deck{"S", "M"}, issueVotes)
+	require.ErrorIs(t, err, ErrNoNumericCards)
+}
+
+func TestIndexHintAbstained(t *testing.T) {
+	defaultStrategy, err := NewHintStrategy("", DefaultHintThresholds)
+	require.NoError(t, err)
+	deck := protocol.Deck{"1", "2", "3", "5", "?", "☕"}
+
+	issueVotes := buildIssueVotes([]protocol.VoteValue{"3", "3", "?", "☕"})
+	hint, err := GetHint(defaultStrategy, protocol.HintModeIndex, deck, issueVotes)
+	require.NoError(t, err)
+	require.Equal(t, protocol.VoteValue("3"), hint.Value)
+	require.Equal(t, 2, hint.Abstained)
+	require.Nil(t, hint.Stats)
+
+	issueVotes = buildIssueVotes([]protocol.VoteValue{"?"})
+	_, err = GetHint(defaultStrategy, protocol.HintModeIndex, deck, issueVotes)
+	require.ErrorIs(t, err, ErrNoNumericVotes)
+}
@@ -35,6 +35,12 @@ func WithStorage(s storage.Service) Option {
 	}
 }
 
+func WithIssueSync(s IssueSync) Option {
+	return func(g *Game) {
+		g.issueSync = s
+	}
+}
+
 func WithClock(c clockwork.Clock) Option {
 	return func(g *Game) {
 		g.clock = c
@@ -53,6 +59,12 @@ func WithPlayerName(name string) Option {
 	}
 }
 
+func WithSpectator(spectator bool) Option {
+	return func(g *Game) {
+		g.config.Spectator = spectator
+	}
+}
+
 func WithOnlineMessagePeriod(d time.Duration) Option {
 	return func(g *Game) {
 		g.config.OnlineMessagePeriod = d
@@ -70,3 +82,9 @@ func WithPublishStateLoop(enabled bool) Option {
 		g.config.PublishStateLoopEnabled = enabled
 	}
 }
+
+func WithDealerTimeout(d time.Duration) Option {
+	return func(g *Game) {
+		g.config.DealerTimeout = d
+	}
+}
@@ -18,10 +18,12 @@ func TestOptions(t *testing.T) {
 	defer cancel()
 	transport := &mocktransport.MockService{}
 	storage := &mockstorage.MockService{}
+	issueSync := make(fakeIssueSync)
 	logger := zap.NewNop()
 	clock := clockwork.NewFakeClock()
 	enableSymmetricEncryption := gofakeit.Bool()
 	playerName := gofakeit.Username()
+	spectator := gofakeit.Bool()
 	onlineMessagePeriod := time.Duration(gofakeit.Int64())
 	stateMessagePeriod := time.Duration(gofakeit.Int64())
 	publishStateLoop := gofakeit.Bool()
@@ -30,10 +32,12 @@ func TestOptions(t *testing.T) {
 		WithContext(ctx),
 		WithTransport(transport),
 		WithStorage(storage),
+		WithIssueSync(issueSync),
 		WithLogger(logger),
 		WithClock(clock),
 		WithEnableSymmetricEncryption(enableSymmetricEncryption),
 		WithPlayerName(playerName),
+		WithSpectator(spectator),
 		WithOnlineMessagePeriod(onlineMessagePeriod),
 		WithStateMessagePeriod(stateMessagePeriod),
 		WithPublishStateLoop(publishStateLoop),
@@ -44,10 +48,12 @@ func TestOptions(t *testing.T) {
 	require.Equal(t, ctx, game.ctx)
 	require.Equal(t, transport, game.transport)
 	require.Equal(t, storage, game.storage)
+	require.Equal(t, issueSync, game.issueSync)
 	require.Equal(t, logger, game.logger)
 	require.Equal(t, clock, game.clock)
 	require.Equal(t, enableSymmetricEncryption, game.config.EnableSymmetricEncryption)
 	require.Equal(t, playerName, game.config.PlayerName)
+	require.Equal(t, spectator, game.config.Spectator)
 	require.Equal(t, onlineMessagePeriod, game.config.OnlineMessagePeriod)
 	require.Equal(t, stateMessagePeriod, game.config.StateMessagePeriod)
 	require.Equal(t, publishStateLoop, game.config.PublishStateLoopEnabled)
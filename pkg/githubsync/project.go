@@ -0,0 +1,152 @@
+package githubsync
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/internal/githubapi"
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+// Projects v2 are only available in the GraphQL API
+const projectItemsQuery = `query($owner: String!, $repo: String!, $number: Int!, $field: String!) {
+  repository(owner: $owner, name: $repo) {
+    issue(number: $number) {
+      projectItems(first: 50) {
+        nodes {
+          id
+          project {
+            id
+            number
+            field(name: $field) {
+              ... on ProjectV2Field { id dataType }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const updateFieldMutation = `mutation($project: ID!, $item: ID!, $field: ID!, $value: Float!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: {number: $value}}) {
+    projectV2Item { id }
+  }
+}`
+
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+}
+
+type projectItemsResponse struct {
+	Data struct {
+		Repository struct {
+			Issue struct {
+				ProjectItems struct {
+					Nodes []struct {
+						ID      string `json:"id"`
+						Project struct {
+							ID     string `json:"id"`
+							Number int    `json:"number"`
+							Field  *struct {
+								ID       string `json:"id"`
+								DataType string `json:"dataType"`
+							} `json:"field"`
+						} `json:"project"`
+					} `json:"nodes"`
+				} `json:"projectItems"`
+			} `json:"issue"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []graphqlError `json:"errors"`
+}
+
+type updateFieldResponse struct {
+	Errors []graphqlError `json:"errors"`
+}
+
+func (s *Sync) setProjectField(ctx context.Context, ref *githubapi.IssueRef, result protocol.VoteValue) error {
+	value, err := strconv.ParseFloat(string(result), 64)
+	if err != nil {
+		return fmt.Errorf("result '%s' is not a number", result)
+	}
+
+	var items projectItemsResponse
+	err = s.graphql(ctx, projectItemsQuery, map[string]interface{}{
+		"owner":  ref.Owner,
+		"repo":   ref.Repo,
+		"number": ref.Number,
+		"field":  s.config.Field,
+	}, &items, &items.Errors)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items.Data.Repository.Issue.ProjectItems.Nodes {
+		if item.Project.Number != s.config.ProjectNumber {
+			continue
+		}
+		field := item.Project.Field
+		if field == nil || field.ID == "" {
+			return fmt.Errorf("field '%s' not found in project %d", s.config.Field, s.config.ProjectNumber)
+		}
+		if field.DataType != "NUMBER" {
+			return fmt.Errorf("field '%s' is not a number field", s.config.Field)
+		}
+
+		var response updateFieldResponse
+		return s.graphql(ctx, updateFieldMutation, map[string]interface{}{
+			"project": item.Project.ID,
+			"item":    item.ID,
+			"field":   field.ID,
+			"value":   value,
+		}, &response, &response.Errors)
+	}
+
+	return fmt.Errorf("issue is not added to project %d", s.config.ProjectNumber)
+}
+
+// graphqlPath is relative to the REST API URL.
+// GitHub Enterprise serves GraphQL at /api/graphql next to /api/v3/.
+func graphqlPath(restPath string) string {
+	if strings.HasSuffix(restPath, "/api/v3/") {
+		return "../graphql"
+	}
+	return "graphql"
+}
+
+// graphql sends the query through the REST client to reuse its authentication.
+// GraphQL returns errors with 200 status code, so they are read from the response.
+func (s *Sync) graphql(ctx context.Context, query string, variables map[string]interface{}, response interface{}, errs *[]graphqlError) error {
+	request, err := s.client.NewRequest("POST", graphqlPath(s.client.BaseURL.Path), graphqlRequest{
+		Query:     query,
+		Variables: variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, request, response)
+	if err != nil {
+		return err
+	}
+
+	if len(*errs) > 0 {
+		messages := make([]string, 0, len(*errs))
+		for _, e := range *errs {
+			messages = append(messages, e.Message)
+		}
+		return errors.New(strings.Join(messages, "; "))
+	}
+
+	return nil
+}
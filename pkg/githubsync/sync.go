@@ -0,0 +1,147 @@
+package githubsync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/internal/githubapi"
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+	"github.com/six78/2-story-points-cli/pkg/report"
+)
+
+// Config of the estimates write-back to GitHub issues
+type Config struct {
+	Token string
+	// LabelPrefix enables the result label, e.g. "sp:" sets "sp:5".
+	// Labels with the same prefix are removed from the issue.
+	LabelPrefix string
+	// ProjectNumber and Field enable writing the result
+	// to a number field of the issue item in a Projects v2 board.
+	ProjectNumber int
+	Field         string
+	// Comment enables posting the vote distribution as an issue comment
+	Comment bool
+	// BaseURL is the GitHub Enterprise API URL, github.com is used when empty
+	BaseURL string
+}
+
+// Enabled returns true when there is a token and at least one way to write the result
+func (c Config) Enabled() bool {
+	return c.Token != "" && (c.LabelPrefix != "" || c.projectEnabled() || c.Comment)
+}
+
+func (c Config) projectEnabled() bool {
+	return c.ProjectNumber > 0 && c.Field != ""
+}
+
+// Sync writes the results of finished issues back to GitHub
+type Sync struct {
+	config Config
+	client *github.Client
+	host   string
+}
+
+func New(config Config) (*Sync, error) {
+	client, err := githubapi.NewClient(config.Token, config.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sync{
+		config: config,
+		client: client,
+		host:   githubapi.WebHost(config.BaseURL),
+	}, nil
+}
+
+// SyncResult writes the issue result to GitHub.
+// Issues without a result or which are not GitHub issues are ignored.
+func (s *Sync) SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error {
+	if issue.Result == nil {
+		return nil
+	}
+
+	ref, ok := githubapi.ParseIssueURL(issue.TitleOrURL, s.host)
+	if !ok {
+		return nil
+	}
+
+	if s.config.LabelPrefix != "" {
+		err := s.setLabel(ctx, ref, *issue.Result)
+		if err != nil {
+			return errors.Wrap(err, "failed to set result label")
+		}
+	}
+
+	if s.config.projectEnabled() {
+		err := s.setProjectField(ctx, ref, *issue.Result)
+		if err != nil {
+			return errors.Wrap(err, "failed to set project field")
+		}
+	}
+
+	if s.config.Comment {
+		body := commentBody(deck, issue)
+		_, _, err := s.client.Issues.CreateComment(ctx, ref.Owner, ref.Repo, ref.Number, &github.IssueComment{
+			Body: &body,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to post comment")
+		}
+	}
+
+	return nil
+}
+
+// setLabel replaces the previous result label, if any
+func (s *Sync) setLabel(ctx context.Context, ref *githubapi.IssueRef, result protocol.VoteValue) error {
+	label := s.config.LabelPrefix + string(result)
+
+	labels, _, err := s.client.Issues.ListLabelsByIssue(ctx, ref.Owner, ref.Repo, ref.Number, nil)
+	if err != nil {
+		return err
+	}
+
+	exists := false
+	for _, item := range labels {
+		name := item.GetName()
+		if name == label {
+			exists = true
+			continue
+		}
+		if !strings.HasPrefix(name, s.config.LabelPrefix) {
+			continue
+		}
+		_, err = s.client.Issues.RemoveLabelForIssue(ctx, ref.Owner, ref.Repo, ref.Number, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if exists {
+		return nil
+	}
+
+	_, _, err = s.client.Issues.AddLabelsToIssue(ctx, ref.Owner, ref.Repo, ref.Number, []string{label})
+	return err
+}
+
+func commentBody(deck protocol.Deck, issue protocol.Issue) string {
+	lines := []string{
+		fmt.Sprintf("Estimated with 2SP: **%s**", *issue.Result),
+	}
+
+	votes := report.VoteDistribution(deck, issue.Votes)
+	if len(votes) > 0 {
+		lines = append(lines, "", "| Vote | Count |", "|------|-------|")
+		for _, vote := range votes {
+			lines = append(lines, fmt.Sprintf("| %s | %d |", vote.Value, vote.Count))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,211 @@
+package githubsync
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+// The test server is used as GitHub Enterprise
+const issuePath = "/api/v3/repos/six78/2-story-points-cli/issues/42"
+
+type apiCall struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// fakeGitHub is a minimal stand-in for the GitHub API
+type fakeGitHub struct {
+	mutex  sync.Mutex
+	calls  []apiCall
+	labels []string
+	field  string
+}
+
+func (f *fakeGitHub) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(issuePath+"/labels", func(w http.ResponseWriter, r *http.Request) {
+		f.record(t, r)
+		switch r.Method {
+		case http.MethodGet:
+			labels := make([]map[string]string, 0, len(f.labels))
+			for _, label := range f.labels {
+				labels = append(labels, map[string]string{"name": label})
+			}
+			_ = json.NewEncoder(w).Encode(labels)
+		case http.MethodPost:
+			_, _ = w.Write([]byte("[]"))
+		}
+	})
+
+	mux.HandleFunc(issuePath+"/labels/", func(w http.ResponseWriter, r *http.Request) {
+		f.record(t, r)
+		_, _ = w.Write([]byte("[]"))
+	})
+
+	mux.HandleFunc(issuePath+"/comments", func(w http.ResponseWriter, r *http.Request) {
+		f.record(t, r)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		body := f.record(t, r)
+		if strings.HasPrefix(body, `{"query":"mutation`) {
+			_, _ = w.Write([]byte(`{"data": {}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"repository": {"issue": {"projectItems": {"nodes": [
+			{"id": "item-1", "project": {"id": "project-1", "number": 1, "field": null}},
+			{"id": "item-2", "project": {"id": "project-2", "number": 2, "field": ` + f.field + `}}
+		]}}}}}`))
+	})
+
+	return mux
+}
+
+func (f *fakeGitHub) record(t *testing.T, r *http.Request) string {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.calls = append(f.calls, apiCall{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Body:   string(body),
+	})
+	return string(body)
+}
+
+// newTestSync returns the sync and the issue link on the test server
+func newTestSync(t *testing.T, fake *fakeGitHub, config Config) (*Sync, string) {
+	server := httptest.NewServer(fake.handler(t))
+	t.Cleanup(server.Close)
+
+	config.Token = "token"
+	config.BaseURL = server.URL
+	s, err := New(config)
+	require.NoError(t, err)
+	return s, server.URL + "/six78/2-story-points-cli/issues/42"
+}
+
+func testIssue(url string, result protocol.VoteValue) protocol.Issue {
+	return protocol.Issue{
+		TitleOrURL: url,
+		Result:     &result,
+		Votes: protocol.IssueVotes{
+			"a": {Value: "3"},
+			"b": {Value: "5"},
+			"c": {Value: "5"},
+		},
+	}
+}
+
+var testDeck = protocol.Deck{"1", "2", "3", "5", "8", "?"}
+
+func TestConfigEnabled(t *testing.T) {
+	require.False(t, Config{LabelPrefix: "sp:"}.Enabled())
+	require.False(t, Config{Token: "token"}.Enabled())
+	require.False(t, Config{Token: "token", ProjectNumber: 1}.Enabled())
+	require.True(t, Config{Token: "token", LabelPrefix: "sp:"}.Enabled())
+	require.True(t, Config{Token: "token", ProjectNumber: 1, Field: "Estimate"}.Enabled())
+	require.True(t, Config{Token: "token", Comment: true}.Enabled())
+}
+
+func TestSyncLabel(t *testing.T) {
+	fake := &fakeGitHub{labels: []string{"bug", "sp:3"}}
+	s, issueURL := newTestSync(t, fake, Config{LabelPrefix: "sp:"})
+
+	err := s.SyncResult(context.Background(), testDeck, testIssue(issueURL, "5"))
+	require.NoError(t, err)
+
+	require.Len(t, fake.calls, 3)
+	require.Equal(t, http.MethodGet, fake.calls[0].Method)
+	require.Equal(t, apiCall{Method: http.MethodDelete, Path: issuePath + "/labels/sp:3"}, fake.calls[1])
+	require.Equal(t, http.MethodPost, fake.calls[2].Method)
+	require.JSONEq(t, `["sp:5"]`, fake.calls[2].Body)
+
+	// Label already set
+	fake.calls = nil
+	fake.labels = []string{"sp:5"}
+	err = s.SyncResult(context.Background(), testDeck, testIssue(issueURL, "5"))
+	require.NoError(t, err)
+	require.Len(t, fake.calls, 1)
+}
+
+func TestSyncComment(t *testing.T) {
+	fake := &fakeGitHub{}
+	s, issueURL := newTestSync(t, fake, Config{Comment: true})
+
+	err := s.SyncResult(context.Background(), testDeck, testIssue(issueURL, "5"))
+	require.NoError(t, err)
+
+	require.Len(t, fake.calls, 1)
+	require.Equal(t, issuePath+"/comments", fake.calls[0].Path)
+
+	var comment struct {
+		Body string `json:"body"`
+	}
+	err = json.Unmarshal([]byte(fake.calls[0].Body), &comment)
+	require.NoError(t, err)
+	require.Equal(t, "Estimated with 2SP: **5**\n\n| Vote | Count |\n|------|-------|\n| 3 | 1 |\n| 5 | 2 |", comment.Body)
+}
+
+func TestSyncProjectField(t *testing.T) {
+	fake := &fakeGitHub{field: `{"id": "field-2", "dataType": "NUMBER"}`}
+	s, issueURL := newTestSync(t, fake, Config{ProjectNumber: 2, Field: "Estimate"})
+
+	err := s.SyncResult(context.Background(), testDeck, testIssue(issueURL, "5"))
+	require.NoError(t, err)
+
+	require.Len(t, fake.calls, 2)
+	require.Contains(t, fake.calls[0].Body, `"field":"Estimate"`)
+	require.Contains(t, fake.calls[1].Body, `"variables":{"field":"field-2","item":"item-2","project":"project-2","value":5}`)
+
+	// Non-numeric result
+	err = s.SyncResult(context.Background(), testDeck, testIssue(issueURL, "?"))
+	require.Error(t, err)
+
+	// Field is not a number
+	fake.field = `{"id": "field-2", "dataType": "TEXT"}`
+	err = s.SyncResult(context.Background(), testDeck, testIssue(issueURL, "5"))
+	require.Error(t, err)
+
+	// Issue is not in the project
+	s.config.ProjectNumber = 3
+	err = s.SyncResult(context.Background(), testDeck, testIssue(issueURL, "5"))
+	require.Error(t, err)
+}
+
+func TestSyncSkipped(t *testing.T) {
+	fake := &fakeGitHub{}
+	s, issueURL := newTestSync(t, fake, Config{LabelPrefix: "sp:", Comment: true})
+
+	// Not a GitHub issue
+	err := s.SyncResult(context.Background(), testDeck, testIssue("Just a title", "5"))
+	require.NoError(t, err)
+
+	// Issue from another GitHub host
+	err = s.SyncResult(context.Background(), testDeck, testIssue("https://github.com/six78/2-story-points-cli/issues/42", "5"))
+	require.NoError(t, err)
+
+	// No result
+	issue := testIssue(issueURL, "5")
+	issue.Result = nil
+	err = s.SyncResult(context.Background(), testDeck, issue)
+	require.NoError(t, err)
+
+	require.Empty(t, fake.calls)
+}
@@ -0,0 +1,145 @@
+package issueprovider
+
+import (
+	"context"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	titleRegexp = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	metaRegexp  = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attrRegexp  = regexp.MustCompile(`(?is)(property|name|content)\s*=\s*("[^"]*"|'[^']*')`)
+)
+
+// ErrPrivateAddress is returned for links to the local network, which are never fetched
+var ErrPrivateAddress = errors.New("private network address")
+
+// Generic unfurls any web link by the page title and description.
+// It should be the last provider, as it matches all links.
+type Generic struct {
+	client    *http.Client
+	allowedIP func(ip net.IP) bool
+}
+
+func NewGeneric() *Generic {
+	p := &Generic{
+		allowedIP: publicIP,
+	}
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: p.checkAddress,
+	}
+	p.client = &http.Client{
+		Timeout: 30 * time.Second,
+		// No proxy, so that the address of the target itself is checked
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+	return p
+}
+
+func (p *Generic) Name() string {
+	return "web"
+}
+
+func (p *Generic) Match(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func (p *Generic) Fetch(ctx context.Context, link string) (*Info, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/html")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	err = checkStatus(response)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := io.ReadAll(io.LimitReader(response.Body, responseSizeLimit))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read page")
+	}
+
+	return parsePage(string(page)), nil
+}
+
+// checkAddress refuses connections to the local network.
+// It's called for each connection after the host is resolved, so redirects are checked as well.
+func (p *Generic) checkAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !p.allowedIP(ip) {
+		return errors.Wrap(ErrPrivateAddress, host)
+	}
+	return nil
+}
+
+func publicIP(ip net.IP) bool {
+	return !ip.IsPrivate() &&
+		!ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// parsePage prefers Open Graph tags over the page title
+func parsePage(page string) *Info {
+	info := &Info{}
+
+	for _, tag := range metaRegexp.FindAllString(page, -1) {
+		attributes := make(map[string]string)
+		for _, match := range attrRegexp.FindAllStringSubmatch(tag, -1) {
+			attributes[strings.ToLower(match[1])] = html.UnescapeString(strings.Trim(match[2], `"'`))
+		}
+		property := attributes["property"]
+		if property == "" {
+			property = attributes["name"]
+		}
+		switch property {
+		case "og:title":
+			info.Title = attributes["content"]
+		case "og:description":
+			info.Description = attributes["content"]
+		case "description":
+			if info.Description == "" {
+				info.Description = attributes["content"]
+			}
+		}
+	}
+
+	if info.Title == "" {
+		if match := titleRegexp.FindStringSubmatch(page); match != nil {
+			info.Title = html.UnescapeString(strings.TrimSpace(match[1]))
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,106 @@
+package issueprovider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericMatch(t *testing.T) {
+	provider := NewGeneric()
+	require.True(t, provider.Match("https://example.com/issues/1"))
+	require.True(t, provider.Match("http://example.com"))
+	require.False(t, provider.Match("ftp://example.com"))
+	require.False(t, provider.Match("Just a title"))
+}
+
+func TestParsePage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		page     string
+		expected *Info
+	}{
+		{
+			name:     "title",
+			page:     `<html><head><title> Issue &amp; title </title></head></html>`,
+			expected: &Info{Title: "Issue & title"},
+		},
+		{
+			name: "open graph",
+			page: `<head>
+				<title>Page title</title>
+				<meta name="description" content="Page description">
+				<meta property="og:title" content='Issue title' />
+				<meta content="Issue description" property="og:description">
+			</head>`,
+			expected: &Info{Title: "Issue title", Description: "Issue description"},
+		},
+		{
+			name:     "description",
+			page:     `<title>Page title</title><meta name="description" content="Page description">`,
+			expected: &Info{Title: "Page title", Description: "Page description"},
+		},
+		{
+			name:     "empty",
+			page:     `plain text`,
+			expected: &Info{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, parsePage(tc.page))
+		})
+	}
+}
+
+func TestGenericFetch(t *testing.T) {
+	_, server := newFakeTracker(t, func(w http.ResponseWriter, r *http.Request, body string) {
+		if r.URL.Path != "/ticket/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`<title>Ticket 1</title>`))
+	})
+
+	provider := NewGeneric()
+	// Fake tracker listens on the loopback address
+	provider.allowedIP = net.IP.IsLoopback
+
+	info, err := provider.Fetch(context.Background(), server.URL+"/ticket/1")
+	require.NoError(t, err)
+	require.Equal(t, "Ticket 1", info.Title)
+
+	_, err = provider.Fetch(context.Background(), server.URL+"/ticket/2")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGenericPrivateAddress(t *testing.T) {
+	_, server := newFakeTracker(t, func(w http.ResponseWriter, r *http.Request, body string) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	})
+
+	provider := NewGeneric()
+	_, err := provider.Fetch(context.Background(), server.URL)
+	require.ErrorIs(t, err, ErrPrivateAddress)
+
+	provider.allowedIP = net.IP.IsLoopback
+	_, err = provider.Fetch(context.Background(), server.URL)
+	require.ErrorIs(t, err, ErrPrivateAddress)
+}
+
+func TestPublicIP(t *testing.T) {
+	require.True(t, publicIP(net.ParseIP("93.184.216.34")))
+	require.True(t, publicIP(net.ParseIP("2606:2800:220:1::")))
+	require.False(t, publicIP(net.ParseIP("127.0.0.1")))
+	require.False(t, publicIP(net.ParseIP("10.1.2.3")))
+	require.False(t, publicIP(net.ParseIP("192.168.0.1")))
+	require.False(t, publicIP(net.ParseIP("169.254.169.254")))
+	require.False(t, publicIP(net.ParseIP("0.0.0.0")))
+	require.False(t, publicIP(net.ParseIP("::1")))
+	require.False(t, publicIP(net.ParseIP("fe80::1")))
+	require.False(t, publicIP(net.ParseIP("fd00::1")))
+}
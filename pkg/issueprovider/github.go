@@ -0,0 +1,96 @@
+package issueprovider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/internal/githubapi"
+	"github.com/six78/2-story-points-cli/pkg/githubsync"
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+type GitHub struct {
+	client *github.Client
+	host   string
+	sync   *githubsync.Sync
+}
+
+// NewGitHub creates the provider with the estimates write-back, if it's enabled in the config
+func NewGitHub(config githubsync.Config) (*GitHub, error) {
+	client, err := githubapi.NewClient(config.Token, config.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &GitHub{
+		client: client,
+		host:   githubapi.WebHost(config.BaseURL),
+	}
+
+	if config.Enabled() {
+		provider.sync, err = githubsync.New(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return provider, nil
+}
+
+func (p *GitHub) Name() string {
+	return "github"
+}
+
+func (p *GitHub) Match(link string) bool {
+	_, ok := githubapi.ParseIssueURL(link, p.host)
+	return ok
+}
+
+func (p *GitHub) Fetch(ctx context.Context, link string) (*Info, error) {
+	ref, ok := githubapi.ParseIssueURL(link, p.host)
+	if !ok {
+		return nil, errors.New("invalid github issue link")
+	}
+
+	issue, _, err := p.client.Issues.Get(ctx, ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		return nil, githubError(err)
+	}
+
+	labels := make([]Label, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		color := ""
+		if label.Color != nil {
+			color = "#" + label.GetColor()
+		}
+		labels = append(labels, Label{Name: label.GetName(), Color: color})
+	}
+
+	return &Info{
+		Title:       issue.GetTitle(),
+		Description: issue.GetBody(),
+		Labels:      labels,
+		Status:      issue.GetState(),
+	}, nil
+}
+
+func (p *GitHub) SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error {
+	if p.sync == nil {
+		return nil
+	}
+	return p.sync.SyncResult(ctx, deck, issue)
+}
+
+func githubError(err error) error {
+	if reset, ok := githubapi.RateLimitReset(err); ok {
+		return &RateLimitError{Reset: reset}
+	}
+	var responseErr *github.ErrorResponse
+	if errors.As(err, &responseErr) && responseErr.Response != nil && responseErr.Response.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return err
+}
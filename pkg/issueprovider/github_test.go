@@ -0,0 +1,65 @@
+package issueprovider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/six78/2-story-points-cli/pkg/githubsync"
+)
+
+// The test server is used as GitHub Enterprise
+func TestGitHubFetch(t *testing.T) {
+	fake, server := newFakeTracker(t, func(w http.ResponseWriter, r *http.Request, body string) {
+		switch r.URL.Path {
+		case "/api/v3/repos/six78/2-story-points-cli/issues/42":
+			_, _ = w.Write([]byte(`{
+				"title": "Add export",
+				"body": "Export the report",
+				"state": "open",
+				"labels": [{"name": "enhancement", "color": "a2eeef"}, {"name": "plain"}]
+			}`))
+		case "/api/v3/repos/six78/2-story-points-cli/issues/43":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "2000000000")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	})
+
+	provider, err := NewGitHub(githubsync.Config{Token: "token", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	link := server.URL + "/six78/2-story-points-cli/issues/42"
+	require.True(t, provider.Match(link))
+	require.False(t, provider.Match("https://github.com/six78/2-story-points-cli/issues/42"))
+
+	info, err := provider.Fetch(context.Background(), link)
+	require.NoError(t, err)
+	require.Equal(t, &Info{
+		Title:       "Add export",
+		Description: "Export the report",
+		Labels:      []Label{{Name: "enhancement", Color: "#a2eeef"}, {Name: "plain"}},
+		Status:      "open",
+	}, info)
+	require.Equal(t, "Bearer token", fake.calls[0].Header.Get("Authorization"))
+
+	_, err = provider.Fetch(context.Background(), server.URL+"/six78/2-story-points-cli/issues/44")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// go-github keeps the limit, so this is checked last
+	_, err = provider.Fetch(context.Background(), server.URL+"/six78/2-story-points-cli/issues/43")
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	require.Equal(t, int64(2000000000), rateLimitErr.Reset.Unix())
+
+	// Write-back is not configured
+	err = provider.SyncResult(context.Background(), testDeck, testIssue(link, "5"))
+	require.NoError(t, err)
+	require.Len(t, fake.calls, 3)
+}
@@ -0,0 +1,145 @@
+package issueprovider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+const defaultGitLabURL = "https://gitlab.com"
+
+type GitLabConfig struct {
+	// BaseURL of the GitLab instance, gitlab.com is used when empty
+	BaseURL string
+	Token   string
+	// Weight enables writing the result to the issue weight
+	Weight bool
+}
+
+type GitLab struct {
+	config GitLabConfig
+	api    *apiClient
+}
+
+func NewGitLab(config GitLabConfig) (*GitLab, error) {
+	if config.BaseURL == "" {
+		config.BaseURL = defaultGitLabURL
+	}
+	api, err := newAPIClient(config.BaseURL, func(r *http.Request) {
+		if config.Token != "" {
+			r.Header.Set("PRIVATE-TOKEN", config.Token)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GitLab{
+		config: config,
+		api:    api,
+	}, nil
+}
+
+type gitlabIssueRef struct {
+	project string
+	iid     int
+}
+
+// path is the issue API path with the project path used as the project ID
+func (r *gitlabIssueRef) path() string {
+	return fmt.Sprintf("/api/v4/projects/%s/issues/%d", url.PathEscape(r.project), r.iid)
+}
+
+// parseLink accepts links like https://gitlab.com/group/project/-/issues/1
+func (p *GitLab) parseLink(link string) (*gitlabIssueRef, bool) {
+	u, err := url.Parse(link)
+	if err != nil || u.Host != p.api.host() {
+		return nil, false
+	}
+
+	project, number, ok := strings.Cut(strings.Trim(u.Path, "/"), "/-/issues/")
+	if !ok || project == "" {
+		return nil, false
+	}
+
+	iid, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, false
+	}
+
+	return &gitlabIssueRef{project: project, iid: iid}, true
+}
+
+func (p *GitLab) Name() string {
+	return "gitlab"
+}
+
+func (p *GitLab) Match(link string) bool {
+	_, ok := p.parseLink(link)
+	return ok
+}
+
+type gitlabIssue struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	Labels      []struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+}
+
+func (p *GitLab) Fetch(ctx context.Context, link string) (*Info, error) {
+	ref, ok := p.parseLink(link)
+	if !ok {
+		return nil, errors.New("invalid gitlab issue link")
+	}
+
+	var issue gitlabIssue
+	err := p.api.do(ctx, http.MethodGet, ref.path()+"?with_labels_details=true", nil, &issue)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]Label, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, Label{Name: label.Name, Color: label.Color})
+	}
+
+	return &Info{
+		Title:       issue.Title,
+		Description: issue.Description,
+		Labels:      labels,
+		Status:      issue.State,
+	}, nil
+}
+
+func (p *GitLab) SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error {
+	if !p.config.Weight || issue.Result == nil {
+		return nil
+	}
+
+	ref, ok := p.parseLink(issue.TitleOrURL)
+	if !ok {
+		return nil
+	}
+
+	value, err := parseNumber(*issue.Result)
+	if err != nil {
+		return err
+	}
+	if value < 0 || value != math.Trunc(value) {
+		return fmt.Errorf("result '%s' is not a valid gitlab weight", *issue.Result)
+	}
+
+	body := map[string]int{"weight": int(value)}
+	err = p.api.do(ctx, http.MethodPut, ref.path(), body, nil)
+	return errors.Wrap(err, "failed to set issue weight")
+}
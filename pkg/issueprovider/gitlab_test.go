@@ -0,0 +1,87 @@
+package issueprovider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const gitlabIssuePath = "/api/v4/projects/group%2Fsubgroup%2Fproject/issues/7"
+
+func newTestGitLab(t *testing.T, config GitLabConfig) (*GitLab, *fakeTracker, string) {
+	fake, server := newFakeTracker(t, func(w http.ResponseWriter, r *http.Request, body string) {
+		if r.URL.EscapedPath() != gitlabIssuePath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"title": "Fix login",
+			"description": "Login fails",
+			"state": "opened",
+			"labels": [{"name": "bug", "color": "#dc143c"}]
+		}`))
+	})
+
+	config.BaseURL = server.URL
+	config.Token = "token"
+	provider, err := NewGitLab(config)
+	require.NoError(t, err)
+	return provider, fake, server.URL + "/group/subgroup/project/-/issues/7"
+}
+
+func TestGitLabMatch(t *testing.T) {
+	provider, err := NewGitLab(GitLabConfig{})
+	require.NoError(t, err)
+
+	require.True(t, provider.Match("https://gitlab.com/group/project/-/issues/1"))
+	require.True(t, provider.Match("https://gitlab.com/group/sub/project/-/issues/12/"))
+	require.False(t, provider.Match("https://gitlab.com/group/project/-/merge_requests/1"))
+	require.False(t, provider.Match("https://gitlab.com/group/project/-/issues/abc"))
+	require.False(t, provider.Match("https://gitlab.example.com/group/project/-/issues/1"))
+	require.False(t, provider.Match("Just a title"))
+}
+
+func TestGitLabFetch(t *testing.T) {
+	provider, fake, link := newTestGitLab(t, GitLabConfig{})
+
+	info, err := provider.Fetch(context.Background(), link)
+	require.NoError(t, err)
+	require.Equal(t, &Info{
+		Title:       "Fix login",
+		Description: "Login fails",
+		Labels:      []Label{{Name: "bug", Color: "#dc143c"}},
+		Status:      "opened",
+	}, info)
+
+	require.Len(t, fake.calls, 1)
+	require.Equal(t, "token", fake.calls[0].Header.Get("PRIVATE-TOKEN"))
+
+	_, err = provider.Fetch(context.Background(), link[:len(link)-1]+"8")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGitLabSyncResult(t *testing.T) {
+	provider, fake, link := newTestGitLab(t, GitLabConfig{Weight: true})
+
+	err := provider.SyncResult(context.Background(), testDeck, testIssue(link, "5"))
+	require.NoError(t, err)
+	require.Len(t, fake.calls, 1)
+	require.Equal(t, http.MethodPut, fake.calls[0].Method)
+	require.Equal(t, gitlabIssuePath, fake.calls[0].Path)
+	require.JSONEq(t, `{"weight": 5}`, fake.calls[0].Body)
+
+	// Weight must be a non-negative integer
+	err = provider.SyncResult(context.Background(), testDeck, testIssue(link, "0.5"))
+	require.Error(t, err)
+	err = provider.SyncResult(context.Background(), testDeck, testIssue(link, "?"))
+	require.Error(t, err)
+	require.Len(t, fake.calls, 1)
+
+	// Write-back disabled
+	provider.config.Weight = false
+	err = provider.SyncResult(context.Background(), testDeck, testIssue(link, "5"))
+	require.NoError(t, err)
+	require.Len(t, fake.calls, 1)
+}
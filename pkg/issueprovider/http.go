@@ -0,0 +1,170 @@
+package issueprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const responseSizeLimit = 1 << 20
+
+// apiClient sends JSON requests to the issue tracker API
+type apiClient struct {
+	client  *http.Client
+	baseURL *url.URL
+	// authorize adds credentials to the request
+	authorize func(*http.Request)
+}
+
+func newAPIClient(baseURL string, authorize func(*http.Request)) (*apiClient, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base url")
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base url: '%s'", baseURL)
+	}
+	return &apiClient{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		baseURL:   u,
+		authorize: authorize,
+	}, nil
+}
+
+// host is used to match issue links of self-hosted trackers
+func (c *apiClient) host() string {
+	return c.baseURL.Host
+}
+
+// do sends the request body as JSON and decodes the JSON response, if given
+func (c *apiClient) do(ctx context.Context, method string, path string, body interface{}, response interface{}) error {
+	result, err := c.send(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+
+	err = checkStatus(result)
+	if err != nil {
+		return err
+	}
+
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(io.LimitReader(result.Body, responseSizeLimit)).Decode(response)
+}
+
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// graphql sends the query and decodes the response data.
+// GraphQL errors can come with both 200 and 400 status codes.
+func (c *apiClient) graphql(ctx context.Context, path string, query string, variables map[string]interface{}, data interface{}) error {
+	result, err := c.send(ctx, http.MethodPost, path, graphqlRequest{
+		Query:     query,
+		Variables: variables,
+	})
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+
+	if result.StatusCode != http.StatusOK && result.StatusCode != http.StatusBadRequest {
+		return checkStatus(result)
+	}
+
+	var response graphqlResponse
+	err = json.NewDecoder(io.LimitReader(result.Body, responseSizeLimit)).Decode(&response)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode graphql response")
+	}
+
+	if len(response.Errors) > 0 {
+		messages := make([]string, 0, len(response.Errors))
+		for _, e := range response.Errors {
+			if e.Extensions.Code == "RATELIMITED" {
+				return &RateLimitError{Reset: rateLimitReset(result.Header)}
+			}
+			if strings.Contains(strings.ToLower(e.Message), "not found") {
+				return ErrNotFound
+			}
+			messages = append(messages, e.Message)
+		}
+		return errors.New(strings.Join(messages, "; "))
+	}
+
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(response.Data, data)
+}
+
+func (c *apiClient) send(ctx context.Context, method string, path string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.authorize != nil {
+		c.authorize(request)
+	}
+
+	return c.client.Do(request)
+}
+
+func checkStatus(response *http.Response) error {
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case response.StatusCode == http.StatusTooManyRequests:
+		return &RateLimitError{Reset: rateLimitReset(response.Header)}
+	case response.StatusCode >= 400:
+		return fmt.Errorf("unexpected response status: %s", response.Status)
+	}
+	return nil
+}
+
+// rateLimitReset reads Retry-After seconds or RateLimit-Reset unix time
+func rateLimitReset(header http.Header) time.Time {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	if timestamp, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(timestamp, 0)
+	}
+	return time.Now().Add(time.Minute)
+}
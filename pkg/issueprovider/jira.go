@@ -0,0 +1,137 @@
+package issueprovider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+var jiraKeyRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+type JiraConfig struct {
+	// BaseURL of the Jira site, e.g. https://example.atlassian.net
+	BaseURL string
+	// User is the account email for Jira Cloud basic authentication.
+	// When empty, Token is used as a personal access token.
+	User  string
+	Token string
+	// EstimateField enables writing the result to the field, e.g. "customfield_10016"
+	EstimateField string
+}
+
+type Jira struct {
+	config JiraConfig
+	api    *apiClient
+}
+
+func NewJira(config JiraConfig) (*Jira, error) {
+	if config.BaseURL == "" {
+		return nil, errors.New("jira base url is required")
+	}
+	api, err := newAPIClient(config.BaseURL, func(r *http.Request) {
+		switch {
+		case config.User != "":
+			r.SetBasicAuth(config.User, config.Token)
+		case config.Token != "":
+			r.Header.Set("Authorization", "Bearer "+config.Token)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Jira{
+		config: config,
+		api:    api,
+	}, nil
+}
+
+// parseKey returns the issue key from links like https://example.atlassian.net/browse/PROJ-1
+func (p *Jira) parseKey(link string) (string, bool) {
+	u, err := url.Parse(link)
+	if err != nil || u.Host != p.api.host() {
+		return "", false
+	}
+
+	key, ok := strings.CutPrefix(strings.TrimSuffix(u.Path, "/"), p.api.baseURL.Path+"/browse/")
+	if !ok || !jiraKeyRegexp.MatchString(key) {
+		return "", false
+	}
+
+	return key, true
+}
+
+func (p *Jira) Name() string {
+	return "jira"
+}
+
+func (p *Jira) Match(link string) bool {
+	_, ok := p.parseKey(link)
+	return ok
+}
+
+type jiraIssue struct {
+	Fields struct {
+		Summary     string   `json:"summary"`
+		Description string   `json:"description"`
+		Labels      []string `json:"labels"`
+		Status      struct {
+			Name string `json:"name"`
+		} `json:"status"`
+	} `json:"fields"`
+}
+
+func (p *Jira) Fetch(ctx context.Context, link string) (*Info, error) {
+	key, ok := p.parseKey(link)
+	if !ok {
+		return nil, errors.New("invalid jira issue link")
+	}
+
+	var issue jiraIssue
+	path := "/rest/api/2/issue/" + key + "?fields=summary,description,labels,status"
+	err := p.api.do(ctx, http.MethodGet, path, nil, &issue)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]Label, 0, len(issue.Fields.Labels))
+	for _, label := range issue.Fields.Labels {
+		labels = append(labels, Label{Name: label})
+	}
+
+	return &Info{
+		Title:       issue.Fields.Summary,
+		Description: issue.Fields.Description,
+		Labels:      labels,
+		Status:      issue.Fields.Status.Name,
+	}, nil
+}
+
+func (p *Jira) SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error {
+	if p.config.EstimateField == "" || issue.Result == nil {
+		return nil
+	}
+
+	key, ok := p.parseKey(issue.TitleOrURL)
+	if !ok {
+		return nil
+	}
+
+	value, err := parseNumber(*issue.Result)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"fields": map[string]float64{
+			p.config.EstimateField: value,
+		},
+	}
+	err = p.api.do(ctx, http.MethodPut, "/rest/api/2/issue/"+key, body, nil)
+	return errors.Wrap(err, "failed to set estimate field")
+}
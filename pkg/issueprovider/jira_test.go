@@ -0,0 +1,95 @@
+package issueprovider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJira(t *testing.T, config JiraConfig) (*Jira, *fakeTracker, string) {
+	fake, server := newFakeTracker(t, func(w http.ResponseWriter, r *http.Request, body string) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"key": "PROJ-1", "fields": {
+			"summary": "Add export",
+			"description": "Export the report",
+			"labels": ["backend"],
+			"status": {"name": "In Progress"}
+		}}`))
+	})
+
+	config.BaseURL = server.URL
+	provider, err := NewJira(config)
+	require.NoError(t, err)
+	return provider, fake, server.URL + "/browse/PROJ-1"
+}
+
+func TestJiraMatch(t *testing.T) {
+	_, err := NewJira(JiraConfig{})
+	require.Error(t, err)
+
+	provider, err := NewJira(JiraConfig{BaseURL: "https://example.atlassian.net"})
+	require.NoError(t, err)
+
+	require.True(t, provider.Match("https://example.atlassian.net/browse/PROJ-1"))
+	require.True(t, provider.Match("https://example.atlassian.net/browse/AB2-123/"))
+	require.False(t, provider.Match("https://example.atlassian.net/browse/proj-1"))
+	require.False(t, provider.Match("https://example.atlassian.net/projects/PROJ"))
+	require.False(t, provider.Match("https://other.atlassian.net/browse/PROJ-1"))
+
+	// Jira Server with a context path
+	provider, err = NewJira(JiraConfig{BaseURL: "https://example.com/jira"})
+	require.NoError(t, err)
+	require.True(t, provider.Match("https://example.com/jira/browse/PROJ-1"))
+	require.False(t, provider.Match("https://example.com/browse/PROJ-1"))
+}
+
+func TestJiraFetch(t *testing.T) {
+	provider, fake, link := newTestJira(t, JiraConfig{User: "user@example.com", Token: "token"})
+
+	info, err := provider.Fetch(context.Background(), link)
+	require.NoError(t, err)
+	require.Equal(t, &Info{
+		Title:       "Add export",
+		Description: "Export the report",
+		Labels:      []Label{{Name: "backend"}},
+		Status:      "In Progress",
+	}, info)
+
+	require.Len(t, fake.calls, 1)
+	user, password, ok := (&http.Request{Header: fake.calls[0].Header}).BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "user@example.com", user)
+	require.Equal(t, "token", password)
+
+	_, err = provider.Fetch(context.Background(), link[:len(link)-1]+"2")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestJiraSyncResult(t *testing.T) {
+	provider, fake, link := newTestJira(t, JiraConfig{Token: "token", EstimateField: "customfield_10016"})
+
+	err := provider.SyncResult(context.Background(), testDeck, testIssue(link, "0.5"))
+	require.NoError(t, err)
+	require.Len(t, fake.calls, 1)
+	require.Equal(t, http.MethodPut, fake.calls[0].Method)
+	require.Equal(t, "Bearer token", fake.calls[0].Header.Get("Authorization"))
+	require.JSONEq(t, `{"fields": {"customfield_10016": 0.5}}`, fake.calls[0].Body)
+
+	err = provider.SyncResult(context.Background(), testDeck, testIssue(link, "?"))
+	require.Error(t, err)
+
+	// Write-back disabled
+	provider.config.EstimateField = ""
+	err = provider.SyncResult(context.Background(), testDeck, testIssue(link, "5"))
+	require.NoError(t, err)
+	require.Len(t, fake.calls, 1)
+}
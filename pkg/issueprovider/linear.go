@@ -0,0 +1,159 @@
+package issueprovider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+const (
+	defaultLinearURL = "https://api.linear.app"
+	linearHost       = "linear.app"
+)
+
+var linearIdentifierRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9]*-[0-9]+$`)
+
+const linearIssueQuery = `query($id: String!) {
+  issue(id: $id) {
+    title
+    description
+    state { name }
+    labels { nodes { name color } }
+  }
+}`
+
+const linearEstimateMutation = `mutation($id: String!, $estimate: Int!) {
+  issueUpdate(id: $id, input: {estimate: $estimate}) { success }
+}`
+
+type LinearConfig struct {
+	// BaseURL of the Linear API, api.linear.app is used when empty
+	BaseURL string
+	// Token is a personal API key
+	Token string
+	// Estimate enables writing the result to the issue estimate
+	Estimate bool
+}
+
+type Linear struct {
+	config LinearConfig
+	api    *apiClient
+}
+
+func NewLinear(config LinearConfig) (*Linear, error) {
+	if config.BaseURL == "" {
+		config.BaseURL = defaultLinearURL
+	}
+	api, err := newAPIClient(config.BaseURL, func(r *http.Request) {
+		r.Header.Set("Authorization", config.Token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Linear{
+		config: config,
+		api:    api,
+	}, nil
+}
+
+// parseIdentifier returns the issue identifier from links like
+// https://linear.app/workspace/issue/ENG-1/issue-title
+func parseIdentifier(link string) (string, bool) {
+	u, err := url.Parse(link)
+	if err != nil || u.Host != linearHost {
+		return "", false
+	}
+
+	path := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(path) < 3 || path[1] != "issue" || !linearIdentifierRegexp.MatchString(path[2]) {
+		return "", false
+	}
+
+	return path[2], true
+}
+
+func (p *Linear) Name() string {
+	return "linear"
+}
+
+func (p *Linear) Match(link string) bool {
+	_, ok := parseIdentifier(link)
+	return ok
+}
+
+type linearIssueData struct {
+	Issue *struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		State       struct {
+			Name string `json:"name"`
+		} `json:"state"`
+		Labels struct {
+			Nodes []struct {
+				Name  string `json:"name"`
+				Color string `json:"color"`
+			} `json:"nodes"`
+		} `json:"labels"`
+	} `json:"issue"`
+}
+
+func (p *Linear) Fetch(ctx context.Context, link string) (*Info, error) {
+	id, ok := parseIdentifier(link)
+	if !ok {
+		return nil, errors.New("invalid linear issue link")
+	}
+
+	var data linearIssueData
+	err := p.api.graphql(ctx, "/graphql", linearIssueQuery, map[string]interface{}{"id": id}, &data)
+	if err != nil {
+		return nil, err
+	}
+	if data.Issue == nil {
+		return nil, ErrNotFound
+	}
+
+	labels := make([]Label, 0, len(data.Issue.Labels.Nodes))
+	for _, label := range data.Issue.Labels.Nodes {
+		labels = append(labels, Label{Name: label.Name, Color: label.Color})
+	}
+
+	return &Info{
+		Title:       data.Issue.Title,
+		Description: data.Issue.Description,
+		Labels:      labels,
+		Status:      data.Issue.State.Name,
+	}, nil
+}
+
+func (p *Linear) SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error {
+	if !p.config.Estimate || issue.Result == nil {
+		return nil
+	}
+
+	id, ok := parseIdentifier(issue.TitleOrURL)
+	if !ok {
+		return nil
+	}
+
+	value, err := parseNumber(*issue.Result)
+	if err != nil {
+		return err
+	}
+	if value != math.Trunc(value) {
+		return fmt.Errorf("result '%s' is not a valid linear estimate", *issue.Result)
+	}
+
+	err = p.api.graphql(ctx, "/graphql", linearEstimateMutation, map[string]interface{}{
+		"id":       id,
+		"estimate": int(value),
+	}, nil)
+	return errors.Wrap(err, "failed to set issue estimate")
+}
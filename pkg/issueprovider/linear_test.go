@@ -0,0 +1,100 @@
+package issueprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const linearLink = "https://linear.app/six78/issue/ENG-12/add-export"
+
+func newTestLinear(t *testing.T, config LinearConfig, response string) (*Linear, *fakeTracker) {
+	fake, server := newFakeTracker(t, func(w http.ResponseWriter, r *http.Request, body string) {
+		if r.URL.Path != "/graphql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	})
+
+	config.BaseURL = server.URL
+	config.Token = "token"
+	provider, err := NewLinear(config)
+	require.NoError(t, err)
+	return provider, fake
+}
+
+func TestLinearMatch(t *testing.T) {
+	provider, err := NewLinear(LinearConfig{})
+	require.NoError(t, err)
+
+	require.True(t, provider.Match(linearLink))
+	require.True(t, provider.Match("https://linear.app/six78/issue/ENG-12"))
+	require.False(t, provider.Match("https://linear.app/six78/project/export"))
+	require.False(t, provider.Match("https://linear.app/six78/issue/eng-12"))
+	require.False(t, provider.Match("https://example.com/six78/issue/ENG-12"))
+}
+
+func TestLinearFetch(t *testing.T) {
+	provider, fake := newTestLinear(t, LinearConfig{}, `{"data": {"issue": {
+		"title": "Add export",
+		"description": "Export the report",
+		"state": {"name": "Todo"},
+		"labels": {"nodes": [{"name": "Feature", "color": "#bb87fc"}]}
+	}}}`)
+
+	info, err := provider.Fetch(context.Background(), linearLink)
+	require.NoError(t, err)
+	require.Equal(t, &Info{
+		Title:       "Add export",
+		Description: "Export the report",
+		Labels:      []Label{{Name: "Feature", Color: "#bb87fc"}},
+		Status:      "Todo",
+	}, info)
+
+	require.Len(t, fake.calls, 1)
+	require.Equal(t, "token", fake.calls[0].Header.Get("Authorization"))
+
+	var request graphqlRequest
+	err = json.Unmarshal([]byte(fake.calls[0].Body), &request)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"id": "ENG-12"}, request.Variables)
+}
+
+func TestLinearFetchErrors(t *testing.T) {
+	provider, _ := newTestLinear(t, LinearConfig{}, `{"errors": [{"message": "Entity not found: Issue"}]}`)
+	_, err := provider.Fetch(context.Background(), linearLink)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	provider, _ = newTestLinear(t, LinearConfig{}, `{"errors": [{"message": "Rate limit exceeded", "extensions": {"code": "RATELIMITED"}}]}`)
+	_, err = provider.Fetch(context.Background(), linearLink)
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+
+	provider, _ = newTestLinear(t, LinearConfig{}, `{"errors": [{"message": "Authentication required"}]}`)
+	_, err = provider.Fetch(context.Background(), linearLink)
+	require.EqualError(t, err, "Authentication required")
+}
+
+func TestLinearSyncResult(t *testing.T) {
+	provider, fake := newTestLinear(t, LinearConfig{Estimate: true}, `{"data": {"issueUpdate": {"success": true}}}`)
+
+	err := provider.SyncResult(context.Background(), testDeck, testIssue(linearLink, "8"))
+	require.NoError(t, err)
+	require.Len(t, fake.calls, 1)
+	require.Contains(t, fake.calls[0].Body, `"variables":{"estimate":8,"id":"ENG-12"}`)
+
+	// Estimate must be an integer
+	err = provider.SyncResult(context.Background(), testDeck, testIssue(linearLink, "0.5"))
+	require.Error(t, err)
+	require.Len(t, fake.calls, 1)
+
+	// Write-back disabled
+	provider.config.Estimate = false
+	err = provider.SyncResult(context.Background(), testDeck, testIssue(linearLink, "8"))
+	require.NoError(t, err)
+	require.Len(t, fake.calls, 1)
+}
@@ -0,0 +1,88 @@
+package issueprovider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+var ErrNotFound = errors.New("issue not found")
+
+// RateLimitError is returned when the issue tracker API limit is exhausted
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded until %s", e.Reset.Format(time.RFC3339))
+}
+
+// Label of the issue. Color is a hex "#rrggbb" value, empty when unknown.
+type Label struct {
+	Name  string
+	Color string
+}
+
+// Info is the issue information fetched from the issue tracker
+type Info struct {
+	Title       string
+	Description string
+	Labels      []Label
+	Status      string
+}
+
+// Provider unfurls issue links of an issue tracker
+type Provider interface {
+	// Name is used in messages, e.g. "github"
+	Name() string
+	// Match returns true when the link points to an issue of this provider
+	Match(link string) bool
+	Fetch(ctx context.Context, link string) (*Info, error)
+}
+
+// EstimateWriter is optionally implemented by providers,
+// which can save the finished issue result to the issue tracker
+type EstimateWriter interface {
+	SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error
+}
+
+// Providers are checked in order, so the generic provider should go last
+type Providers []Provider
+
+// Find returns nil if no provider matches the link
+func (p Providers) Find(link string) Provider {
+	for _, provider := range p {
+		if provider.Match(link) {
+			return provider
+		}
+	}
+	return nil
+}
+
+// SyncResult writes the result with the provider of the issue link.
+// Issues of providers without estimate write-back are ignored.
+func (p Providers) SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error {
+	provider := p.Find(issue.TitleOrURL)
+	if provider == nil {
+		return nil
+	}
+	writer, ok := provider.(EstimateWriter)
+	if !ok {
+		return nil
+	}
+	return writer.SyncResult(ctx, deck, issue)
+}
+
+// parseNumber converts the result for trackers with numeric estimates
+func parseNumber(result protocol.VoteValue) (float64, error) {
+	value, err := strconv.ParseFloat(string(result), 64)
+	if err != nil {
+		return 0, fmt.Errorf("result '%s' is not a number", result)
+	}
+	return value, nil
+}
@@ -0,0 +1,160 @@
+package issueprovider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+type apiCall struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   string
+}
+
+// fakeTracker is a minimal stand-in for an issue tracker API.
+// The handler writes the response, all requests are recorded.
+type fakeTracker struct {
+	mutex   sync.Mutex
+	calls   []apiCall
+	handler func(w http.ResponseWriter, r *http.Request, body string)
+}
+
+func newFakeTracker(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, body string)) (*fakeTracker, *httptest.Server) {
+	fake := &fakeTracker{handler: handler}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		fake.mutex.Lock()
+		fake.calls = append(fake.calls, apiCall{
+			Method: r.Method,
+			Path:   r.URL.EscapedPath(),
+			Header: r.Header.Clone(),
+			Body:   string(body),
+		})
+		fake.mutex.Unlock()
+
+		fake.handler(w, r, string(body))
+	}))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func testIssue(url string, result protocol.VoteValue) protocol.Issue {
+	return protocol.Issue{
+		TitleOrURL: url,
+		Result:     &result,
+	}
+}
+
+var testDeck = protocol.Deck{"1", "2", "3", "5", "8", "?"}
+
+// fakeProvider matches links with the given prefix
+type fakeProvider struct {
+	name   string
+	prefix string
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) Match(link string) bool {
+	return len(link) >= len(p.prefix) && link[:len(p.prefix)] == p.prefix
+}
+
+func (p *fakeProvider) Fetch(ctx context.Context, link string) (*Info, error) {
+	return &Info{Title: p.name}, nil
+}
+
+type fakeWriter struct {
+	fakeProvider
+	synced []protocol.Issue
+}
+
+func (p *fakeWriter) SyncResult(ctx context.Context, deck protocol.Deck, issue protocol.Issue) error {
+	p.synced = append(p.synced, issue)
+	return nil
+}
+
+func TestProvidersFind(t *testing.T) {
+	first := &fakeProvider{name: "first", prefix: "https://example.com/issues/"}
+	second := &fakeProvider{name: "second", prefix: "https://"}
+	providers := Providers{first, second}
+
+	require.Equal(t, first, providers.Find("https://example.com/issues/1"))
+	require.Equal(t, second, providers.Find("https://example.org"))
+	require.Nil(t, providers.Find("Just a title"))
+	require.Nil(t, Providers{}.Find("https://example.com"))
+}
+
+func TestProvidersSyncResult(t *testing.T) {
+	writer := &fakeWriter{fakeProvider: fakeProvider{name: "writer", prefix: "https://example.com/"}}
+	providers := Providers{writer, &fakeProvider{name: "reader", prefix: "https://"}}
+
+	issue := testIssue("https://example.com/1", "5")
+	err := providers.SyncResult(context.Background(), testDeck, issue)
+	require.NoError(t, err)
+	require.Equal(t, []protocol.Issue{issue}, writer.synced)
+
+	// Provider without write-back
+	err = providers.SyncResult(context.Background(), testDeck, testIssue("https://example.org/1", "5"))
+	require.NoError(t, err)
+
+	// No provider
+	err = providers.SyncResult(context.Background(), testDeck, testIssue("Just a title", "5"))
+	require.NoError(t, err)
+	require.Len(t, writer.synced, 1)
+}
+
+func TestCheckStatus(t *testing.T) {
+	_, server := newFakeTracker(t, func(w http.ResponseWriter, r *http.Request, body string) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/limited":
+			w.Header().Set("RateLimit-Reset", "2000000000")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+
+	api, err := newAPIClient(server.URL, nil)
+	require.NoError(t, err)
+
+	err = api.do(context.Background(), http.MethodGet, "/missing", nil, nil)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = api.do(context.Background(), http.MethodGet, "/limited", nil, nil)
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	require.Equal(t, int64(2000000000), rateLimitErr.Reset.Unix())
+
+	err = api.do(context.Background(), http.MethodGet, "/broken", nil, nil)
+	require.Error(t, err)
+
+	err = api.do(context.Background(), http.MethodGet, "/ok", nil, nil)
+	require.NoError(t, err)
+}
+
+func TestNewAPIClient(t *testing.T) {
+	_, err := newAPIClient("example.com", nil)
+	require.Error(t, err)
+
+	api, err := newAPIClient("https://jira.example.com/jira/", nil)
+	require.NoError(t, err)
+	require.Equal(t, "jira.example.com", api.host())
+	require.Equal(t, "/jira", api.baseURL.Path)
+}
@@ -0,0 +1,31 @@
+package protocol
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+)
+
+const voteSaltLength = 32
+
+func GenerateVoteSalt() ([]byte, error) {
+	salt := make([]byte, voteSaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// NewVoteCommitment returns a hash commitment of given vote.
+// The salt prevents guessing the vote by hashing all cards of the deck.
+func NewVoteCommitment(value VoteValue, salt []byte) []byte {
+	hash := sha256.New()
+	hash.Write(salt)
+	hash.Write([]byte(value))
+	return hash.Sum(nil)
+}
+
+func VerifyVoteCommitment(commitment []byte, value VoteValue, salt []byte) bool {
+	return len(salt) == voteSaltLength && bytes.Equal(commitment, NewVoteCommitment(value, salt))
+}
@@ -0,0 +1,70 @@
+package protocol
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"github.com/pkg/errors"
+)
+
+// PublicKey is a compressed secp256k1 public key.
+// State.DealerKey is used by players to encrypt their votes, so that only the dealer can read them.
+type PublicKey []byte
+
+func NewPublicKey(key *ecdsa.PublicKey) PublicKey {
+	return crypto.CompressPubkey(key)
+}
+
+func (k PublicKey) Empty() bool {
+	return len(k) == 0
+}
+
+func (k PublicKey) ECDSA() (*ecdsa.PublicKey, error) {
+	if k.Empty() {
+		return nil, errors.New("empty public key")
+	}
+	return crypto.DecompressPubkey(k)
+}
+
+func GeneratePrivateKey() (*ecdsa.PrivateKey, error) {
+	return crypto.GenerateKey()
+}
+
+// EncryptPrivateMessage encrypts the payload to given public key and wraps it into a PrivateMessage.
+// Only the owner of the corresponding private key is able to read the original payload.
+func EncryptPrivateMessage(payload []byte, publicKey *ecdsa.PublicKey) ([]byte, error) {
+	encrypted, err := ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(publicKey), payload, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt payload")
+	}
+
+	return json.Marshal(PrivateMessage{
+		Message: Message{
+			Type: MessageTypePrivate,
+		},
+		Payload: encrypted,
+	})
+}
+
+// DecryptPrivateMessage unwraps a PrivateMessage and returns the original payload
+func DecryptPrivateMessage(payload []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	var message PrivateMessage
+	err := json.Unmarshal(payload, &message)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal message")
+	}
+
+	if message.Type != MessageTypePrivate {
+		return nil, errors.New("message is not a private message")
+	}
+
+	decrypted, err := ecies.ImportECDSA(privateKey).Decrypt(message.Payload, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt payload")
+	}
+
+	return decrypted, nil
+}
@@ -1,9 +1,87 @@
 package protocol
 
-import "golang.org/x/exp/slices"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	MaxDeckSize        = 32
+	MaxCardValueLength = 8
+)
 
 type Deck []VoteValue
 
+// specialCards don't represent an estimate, but an opinion about the issue:
+// "?" - not sure how to estimate, "☕" - need a break, "∞" - the issue is too big.
+var specialCards = []VoteValue{"?", "☕", "∞"}
+
+// Special returns true for special cards, which are not a part of the deck scale.
+// Such votes are not taken into account when calculating the hint.
+func (v VoteValue) Special() bool {
+	return slices.Contains(specialCards, v)
+}
+
+// Number parses the card value in real units.
+// Fractions like "½" and unit suffixes like "h" in "4h" are supported.
+func (v VoteValue) Number() (float64, bool) {
+	if v.Special() {
+		return 0, false
+	}
+
+	value := strings.TrimRightFunc(strings.TrimSpace(string(v)), unicode.IsLetter)
+
+	fraction := 0.0
+	if strings.HasSuffix(value, "½") {
+		fraction = 0.5
+		value = strings.TrimSuffix(value, "½")
+	}
+
+	if value == "" {
+		return fraction, fraction > 0
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return number + fraction, true
+}
+
 func (d Deck) Index(value VoteValue) int {
 	return slices.Index(d, value)
 }
+
+// Validate checks that the deck can be used for voting.
+// It's called on each received state, as the deck is defined by the dealer.
+func (d Deck) Validate() error {
+	if len(d) == 0 {
+		return errors.New("deck can't be empty")
+	}
+	if len(d) > MaxDeckSize {
+		return fmt.Errorf("deck is too big, maximum %d cards allowed", MaxDeckSize)
+	}
+
+	cards := make(map[VoteValue]struct{}, len(d))
+	for _, card := range d {
+		if card == "" {
+			return errors.New("card can't be empty")
+		}
+		if utf8.RuneCountInString(string(card)) > MaxCardValueLength {
+			return fmt.Errorf("card '%s' is too long, maximum %d characters allowed", card, MaxCardValueLength)
+		}
+		if _, ok := cards[card]; ok {
+			return fmt.Errorf("duplicate card: '%s'", card)
+		}
+		cards[card] = struct{}{}
+	}
+
+	return nil
+}
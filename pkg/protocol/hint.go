@@ -4,17 +4,41 @@ type Hint struct {
 	// Acceptable shows if the voting for given issue can be considered as "acceptable".
 	// It will be false if the variety of votes is too high. In this case Advice will contain
 	// a suggestion to discuss and re-vote.
-	Acceptable bool
+	Acceptable bool `json:"acceptable"`
 
 	// RejectReason contains an explanation of why the vote is not acceptable.
 	// When Acceptable is true, RejectReason is empty.
-	RejectReason string
+	RejectReason string `json:"rejectReason,omitempty"`
 
 	// Value is the recommended value for the issue.
 	// It's guaranteed to be one of the values from the deck.
-	Value VoteValue
+	Value VoteValue `json:"value"`
 
 	// Advice is a text advice for the team about current vote.
 	// It might contain players mentions in form "@<id>", where <id> a particular player ID.
-	Advice string
+	Advice string `json:"advice,omitempty"`
+
+	// Abstained is the number of votes, which were not taken into account,
+	// because they are special cards, like "?" or "☕".
+	Abstained int `json:"abstained,omitempty"`
+
+	// Stats contains statistics of votes in real units.
+	// It's only calculated in HintModeNumeric.
+	Stats *HintStats `json:"stats,omitempty"`
+}
+
+// MentionPrefix starts a player mention in the hint advice
+const MentionPrefix = "@"
+
+// Mention formats a player mention for the hint advice.
+// Clients are expected to render it with the player name.
+func Mention(playerID PlayerID) string {
+	return MentionPrefix + string(playerID)
+}
+
+type HintStats struct {
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	// Spread is the difference between the highest and the lowest votes
+	Spread float64 `json:"spread"`
 }
@@ -11,7 +11,36 @@ func (l IssuesList) Get(id IssueID) *Issue {
 	return nil
 }
 
+// Index returns -1 if the issue is not found
+func (l IssuesList) Index(id IssueID) int {
+	for i, issue := range l {
+		if issue.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// HasTitleOrURL checks if the issue with given title or URL is already in the list
+func (l IssuesList) HasTitleOrURL(titleOrURL string) bool {
+	for _, issue := range l {
+		if issue.TitleOrURL == titleOrURL {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNextIssueToDeal returns the next issue without a result, starting from the finished one.
+// Deferred issues are only returned when there are no other issues left.
 func (l IssuesList) GetNextIssueToDeal(finishedIssueID IssueID) IssueID {
+	if next := l.nextIssueToDeal(finishedIssueID, false); next != "" {
+		return next
+	}
+	return l.nextIssueToDeal(finishedIssueID, true)
+}
+
+func (l IssuesList) nextIssueToDeal(finishedIssueID IssueID, deferred bool) IssueID {
 	finishedIssueIndex := -1
 	for i, issue := range l {
 		if issue.ID != finishedIssueID {
@@ -19,13 +48,16 @@ func (l IssuesList) GetNextIssueToDeal(finishedIssueID IssueID) IssueID {
 		}
 		finishedIssueIndex = i
 	}
+	available := func(issue *Issue) bool {
+		return issue.Result == nil && issue.Deferred == deferred && issue.ID != finishedIssueID
+	}
 	for i := finishedIssueIndex; i < len(l); i++ {
-		if l[i].Result == nil {
+		if i >= 0 && available(l[i]) {
 			return l[i].ID
 		}
 	}
 	for i := 0; i < finishedIssueIndex; i++ {
-		if l[i].Result == nil {
+		if available(l[i]) {
 			return l[i].ID
 		}
 	}
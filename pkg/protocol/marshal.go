@@ -19,7 +19,7 @@ func UnmarshalMessage(payload []byte) (*Message, error) {
 func UnmarshalState(payload []byte) (*State, error) {
 	state := GameStateMessage{}
 
-	err := json.Unmarshal(payload, &state)
+	_, err := UnmarshalSignedMessage(payload, &state)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal message")
 	}
@@ -34,7 +34,7 @@ func UnmarshalState(payload []byte) (*State, error) {
 func UnmarshalPlayerVote(payload []byte) (*PlayerVoteMessage, error) {
 	vote := PlayerVoteMessage{}
 
-	err := json.Unmarshal(payload, &vote)
+	_, err := UnmarshalSignedMessage(payload, &vote)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to unmarshal message")
 	}
@@ -11,15 +11,67 @@ type Issue struct {
 	Votes      IssueVotes `json:"votes"`
 	Result     *VoteValue `json:"result"` // NOTE: keep pointer. Because "empty string means vote is not revealed"
 	Hint       *Hint      `json:"-"`
+
+	IssueMetadata
+
+	// VotingDeadline is the unix time in milliseconds, when the dealer reveals the votes.
+	// Zero when voting is not timeboxed.
+	VotingDeadline int64 `json:"votingDeadline,omitempty"`
+
+	// RevealedAt is the unix time in milliseconds, when the votes of current round were revealed
+	RevealedAt int64 `json:"revealedAt,omitempty"`
+
+	// Rounds are the previous voting rounds, oldest first.
+	// Votes, Hint and RevealedAt of the issue belong to the current round.
+	Rounds []VotingRound `json:"rounds,omitempty"`
+
+	// Deferred issues are skipped when dealing the next issue, until there are no other issues left
+	Deferred bool `json:"deferred,omitempty"`
+}
+
+// IssueMetadata is optional information about the issue, set by the dealer
+type IssueMetadata struct {
+	Description string   `json:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	// ExternalKey is the issue key in an external tracker, e.g. "PROJ-123"
+	ExternalKey string `json:"externalKey,omitempty"`
+	Assignee    string `json:"assignee,omitempty"`
+	// Notes are dealer notes for the discussion
+	Notes string `json:"notes,omitempty"`
+}
+
+func (m IssueMetadata) Empty() bool {
+	return m.Description == "" &&
+		len(m.Labels) == 0 &&
+		m.ExternalKey == "" &&
+		m.Assignee == "" &&
+		m.Notes == ""
+}
+
+// VotingRound is a revealed voting round of an issue, kept when the issue is voted again
+type VotingRound struct {
+	Votes      IssueVotes `json:"votes"`
+	Hint       *Hint      `json:"hint,omitempty"`
+	RevealedAt int64      `json:"revealedAt"`
+}
+
+// RoundsCount includes the current round
+func (i *Issue) RoundsCount() int {
+	return len(i.Rounds) + 1
 }
 
 type MessageType string
 
 const (
-	MessageTypeState         MessageType = "__state"
-	MessageTypePlayerOnline  MessageType = "__player_online"
-	MessageTypePlayerVote    MessageType = "__player_vote"
-	MessageTypePlayerOffline MessageType = "__player_left"
+	MessageTypeState          MessageType = "__state"
+	MessageTypePlayerOnline   MessageType = "__player_online"
+	MessageTypePlayerVote     MessageType = "__player_vote"
+	MessageTypePlayerOffline  MessageType = "__player_left"
+	MessageTypeDealerHandover MessageType = "__dealer_handover"
+	MessageTypePrivate        MessageType = "__private"
+	MessageTypeVoteCommit     MessageType = "__player_vote_commit"
+	MessageTypeVoteOpening    MessageType = "__player_vote_opening"
+	MessageTypeRoomKey        MessageType = "__room_key"
 )
 
 type Message struct {
@@ -27,11 +79,20 @@ type Message struct {
 	Timestamp int64       `json:"updatedAt"` // WARNING: rename to Timestamp
 }
 
+// GameStateMessage is published by the dealer. It's signed with the dealer key,
+// so that players can reject states published by anyone else in the room.
 type GameStateMessage struct {
 	Message
 	State State `json:"state"`
+	// DealerKeySignature is the dealer key signed with the player key of the dealer.
+	// Players check it when another player takes over the dealer role, so that nobody
+	// can take over on behalf of a successor.
+	DealerKeySignature []byte `json:"dealerKeySignature,omitempty"`
 }
 
+// Player messages are signed with the player key, which PlayerID is derived from.
+// This prevents players from sending messages on behalf of each other.
+
 type PlayerOnlineMessage struct {
 	Message
 	Player Player `json:"player,omitempty"`
@@ -49,4 +110,51 @@ type PlayerVoteMessage struct {
 	VoteResult VoteResult `json:"vote"`
 }
 
+// PlayerVoteCommitMessage is used instead of PlayerVoteMessage when RoomSettings.CommitReveal is enabled.
+// Empty Commitment means the vote is retracted.
+type PlayerVoteCommitMessage struct {
+	Message
+	PlayerID   PlayerID `json:"playerId"`
+	Issue      IssueID  `json:"issue"`
+	Commitment []byte   `json:"commitment"`
+}
+
+// PlayerVoteOpeningMessage is published by players after votes are revealed.
+// Dealer verifies it against the commitment received during voting.
+type PlayerVoteOpeningMessage struct {
+	Message
+	PlayerID PlayerID  `json:"playerId"`
+	Issue    IssueID   `json:"issue"`
+	Vote     VoteValue `json:"vote"`
+	Salt     []byte    `json:"salt"`
+}
+
+// DealerHandoverMessage is published by the current dealer to pass the dealer role
+// to another player. State is the dealer state without votes of the current round,
+// players send their votes again to the new dealer.
+// The message is signed with the key of the current dealer.
+type DealerHandoverMessage struct {
+	Message
+	Dealer PlayerID `json:"dealer"`
+	State  State    `json:"state"`
+}
+
+// RoomKeyMessage is sent by the dealer privately to each player to move the game to a new room.
+// Players who don't receive it are left in the old room.
+// The message is signed with the dealer key.
+// DealerTerm and Timestamp allow players to reject replayed messages.
+type RoomKeyMessage struct {
+	Message
+	RoomID     string `json:"roomId"`
+	DealerTerm int    `json:"dealerTerm"`
+}
+
+// PrivateMessage contains another message, encrypted to a particular public key.
+// It's used by players to send votes, so that they can only be read by the dealer,
+// and by the dealer to send the new room key to each player.
+type PrivateMessage struct {
+	Message
+	Payload []byte `json:"payload"`
+}
+
 type IssueVotes map[PlayerID]VoteResult
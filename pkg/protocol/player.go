@@ -1,7 +1,12 @@
 package protocol
 
 import (
+	"crypto/ecdsa"
 	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
 )
 
 type Player struct {
@@ -9,6 +14,10 @@ type Player struct {
 	Name   string   `json:"name"`
 	Online bool     `json:"online"`
 
+	// Spectator can watch the game, but is not allowed to vote.
+	// Spectators are not taken into account in hints and "everyone voted" checks.
+	Spectator bool `json:"spectator,omitempty"`
+
 	// Deprecated: use OnlineTimestamp instead
 	// TODO: Those fields should be removed from json. They shouldn't be part of the protocol.
 	// It should only be used by the dealer to keep the state of the player.
@@ -32,3 +41,21 @@ func (p *Player) ApplyDeprecatedPatchOnSend() {
 func (p *Player) OnlineTime() time.Time {
 	return time.UnixMilli(p.OnlineTimestampMilliseconds)
 }
+
+// NewPlayerID derives the player ID from the player public key.
+// This binds the ID to the key, so that only the key owner is able to sign messages as this player.
+func NewPlayerID(key *ecdsa.PublicKey) PlayerID {
+	return PlayerID(base58.Encode(crypto.CompressPubkey(key)))
+}
+
+// PublicKey returns the public key, which the player ID is derived from
+func (id PlayerID) PublicKey() (PublicKey, error) {
+	decoded, err := base58.Decode(string(id))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode player ID")
+	}
+	if len(decoded) != 33 {
+		return nil, errors.New("player ID is not derived from a public key")
+	}
+	return decoded, nil
+}
@@ -10,3 +10,9 @@ func (l PlayersList) Get(id PlayerID) (Player, bool) {
 	}
 	return Player{}, false
 }
+
+// IsSpectator returns false for unknown players
+func (l PlayersList) IsSpectator(id PlayerID) bool {
+	player, ok := l.Get(id)
+	return ok && player.Spectator
+}
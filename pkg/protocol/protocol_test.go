@@ -1,10 +1,12 @@
 package protocol
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/stretchr/testify/require"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -74,3 +76,234 @@ func TestOnlineTimestampMigrationForward(t *testing.T) {
 	require.Equal(t, player.Name, playerReceived.Name)
 	require.Equal(t, now.UnixMilli(), playerReceived.OnlineTimestamp.UnixMilli())
 }
+
+func TestDealerSuccessors(t *testing.T) {
+	state := State{
+		Players: []Player{
+			{ID: "a", Online: true},
+			{ID: "b", Online: false},
+			{ID: "c", Online: true},
+			{ID: "d", Online: true},
+		},
+		Dealer: "c",
+	}
+
+	require.Equal(t, []PlayerID{"a", "d"}, state.DealerSuccessors())
+}
+
+func TestDealerSupersedes(t *testing.T) {
+	current := &State{Dealer: "b", DealerTerm: 1}
+
+	require.True(t, (&State{Dealer: "c", DealerTerm: 2}).DealerSupersedes(current))
+	require.False(t, (&State{Dealer: "a", DealerTerm: 0}).DealerSupersedes(current))
+	require.True(t, (&State{Dealer: "a", DealerTerm: 1}).DealerSupersedes(current))
+	require.False(t, (&State{Dealer: "c", DealerTerm: 1}).DealerSupersedes(current))
+}
+
+func TestPrivateMessage(t *testing.T) {
+	key, err := GeneratePrivateKey()
+	require.NoError(t, err)
+
+	publicKey, err := NewPublicKey(&key.PublicKey).ECDSA()
+	require.NoError(t, err)
+	require.True(t, key.PublicKey.Equal(publicKey))
+
+	payload := []byte(gofakeit.LoremIpsumSentence(10))
+
+	encrypted, err := EncryptPrivateMessage(payload, publicKey)
+	require.NoError(t, err)
+	require.NotContains(t, string(encrypted), string(payload))
+
+	message, err := UnmarshalMessage(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, MessageTypePrivate, message.Type)
+
+	decrypted, err := DecryptPrivateMessage(encrypted, key)
+	require.NoError(t, err)
+	require.Equal(t, payload, decrypted)
+
+	anotherKey, err := GeneratePrivateKey()
+	require.NoError(t, err)
+
+	_, err = DecryptPrivateMessage(encrypted, anotherKey)
+	require.Error(t, err)
+}
+
+func TestVoteCommitment(t *testing.T) {
+	salt, err := GenerateVoteSalt()
+	require.NoError(t, err)
+
+	commitment := NewVoteCommitment("5", salt)
+	require.True(t, VerifyVoteCommitment(commitment, "5", salt))
+	require.False(t, VerifyVoteCommitment(commitment, "8", salt))
+	require.False(t, VerifyVoteCommitment(commitment, "5", salt[1:]))
+
+	anotherSalt, err := GenerateVoteSalt()
+	require.NoError(t, err)
+	require.False(t, VerifyVoteCommitment(commitment, "5", anotherSalt))
+}
+
+func TestStateMessageSignature(t *testing.T) {
+	key, err := GeneratePrivateKey()
+	require.NoError(t, err)
+	publicKey := NewPublicKey(&key.PublicKey)
+
+	message := GameStateMessage{
+		Message: Message{
+			Type:      MessageTypeState,
+			Timestamp: time.Now().UnixMilli(),
+		},
+		State: State{
+			Players: PlayersList{{ID: "player", Name: "dealer", Online: true, OnlineTimestamp: time.Now()}},
+			Issues: IssuesList{{
+				ID:    "issue",
+				Votes: IssueVotes{"player": VoteResult{Value: "1", Timestamp: 1}},
+			}},
+			ActiveIssue: "issue",
+			Dealer:      "player",
+			DealerKey:   publicKey,
+		},
+	}
+
+	// Message published without the signature is unmarshalled, but not verified
+	unsigned, err := json.Marshal(message)
+	require.NoError(t, err)
+
+	var received GameStateMessage
+	signed, err := UnmarshalSignedMessage(unsigned, &received)
+	require.NoError(t, err)
+	require.Equal(t, message.State.Issues, received.State.Issues)
+	require.ErrorIs(t, signed.VerifySignature(publicKey), ErrMissingSignature)
+
+	signed, err = NewSignedMessage(message, key)
+	require.NoError(t, err)
+	require.Equal(t, MessageTypeState, signed.Type)
+
+	// Signature is verified against the payload as received
+	payload, err := json.Marshal(signed)
+	require.NoError(t, err)
+
+	received = GameStateMessage{}
+	signed, err = UnmarshalSignedMessage(payload, &received)
+	require.NoError(t, err)
+	require.Equal(t, message.State.Issues, received.State.Issues)
+	require.NoError(t, signed.VerifySignature(publicKey))
+
+	// Modified payload is rejected
+	signed.Payload = bytes.Replace(signed.Payload, []byte(`"dealer"`), []byte(`"player"`), 1)
+	require.ErrorIs(t, signed.VerifySignature(publicKey), ErrUnexpectedSigner)
+
+	// Another key is rejected
+	anotherKey, err := GeneratePrivateKey()
+	require.NoError(t, err)
+	signed, err = NewSignedMessage(message, key)
+	require.NoError(t, err)
+	require.ErrorIs(t, signed.VerifySignature(NewPublicKey(&anotherKey.PublicKey)), ErrUnexpectedSigner)
+}
+
+func TestPlayerID(t *testing.T) {
+	key, err := GeneratePrivateKey()
+	require.NoError(t, err)
+
+	playerID := NewPlayerID(&key.PublicKey)
+	publicKey, err := playerID.PublicKey()
+	require.NoError(t, err)
+	require.Equal(t, NewPublicKey(&key.PublicKey), publicKey)
+
+	// Random IDs of older versions are not bound to a key
+	_, err = PlayerID(gofakeit.UUID()).PublicKey()
+	require.Error(t, err)
+}
+
+func TestDeckValidate(t *testing.T) {
+	require.NoError(t, Deck{"1", "2", "3", "?"}.Validate())
+	require.NoError(t, Deck{"XS", "S", "M", "L", "XL", "☕"}.Validate())
+
+	require.Error(t, Deck{}.Validate())
+	require.Error(t, Deck{"1", "", "3"}.Validate())
+	require.Error(t, Deck{"1", "2", "1"}.Validate())
+	require.Error(t, Deck{"1", "too long card"}.Validate())
+
+	bigDeck := make(Deck, 0, MaxDeckSize+1)
+	for i := 0; i <= MaxDeckSize; i++ {
+		bigDeck = append(bigDeck, VoteValue(strconv.Itoa(i)))
+	}
+	require.Error(t, bigDeck.Validate())
+}
+
+func TestVoteValueSpecial(t *testing.T) {
+	require.False(t, VoteValue("1").Special())
+	require.False(t, VoteValue("½").Special())
+	require.False(t, VoteValue("XL").Special())
+	require.True(t, VoteValue("?").Special())
+	require.True(t, VoteValue("☕").Special())
+	require.True(t, VoteValue("∞").Special())
+}
+
+func TestVoteValueNumber(t *testing.T) {
+	testCases := map[VoteValue]float64{
+		"0":   0,
+		"13":  13,
+		"½":   0.5,
+		"1½":  1.5,
+		"0.5": 0.5,
+		"8h":  8,
+	}
+	for value, expected := range testCases {
+		number, ok := value.Number()
+		require.True(t, ok, value)
+		require.Equal(t, expected, number, value)
+	}
+
+	for _, value := range []VoteValue{"?", "☕", "∞", "XS", ""} {
+		_, ok := value.Number()
+		require.False(t, ok, value)
+	}
+}
+
+func TestEveryoneVoted(t *testing.T) {
+	issue := &Issue{ID: "issue", Votes: IssueVotes{}}
+	state := State{
+		Players: PlayersList{
+			{ID: "player", Online: true},
+			{ID: "spectator", Online: true, Spectator: true},
+			{ID: "offline", Online: false},
+		},
+		Issues: IssuesList{issue},
+	}
+	require.False(t, state.EveryoneVoted())
+
+	state.ActiveIssue = issue.ID
+	require.False(t, state.EveryoneVoted())
+
+	issue.Votes["player"] = VoteResult{Value: "1"}
+	require.True(t, state.EveryoneVoted())
+	require.True(t, state.Players.IsSpectator("spectator"))
+	require.False(t, state.Players.IsSpectator("player"))
+	require.False(t, state.Players.IsSpectator("unknown"))
+}
+
+func TestGetNextIssueToDeal(t *testing.T) {
+	result := VoteValue("3")
+	issues := IssuesList{
+		{ID: "a", Result: &result},
+		{ID: "b", Deferred: true},
+		{ID: "c", Result: &result},
+		{ID: "d"},
+	}
+
+	// Deferred issues are skipped
+	require.Equal(t, IssueID("d"), issues.GetNextIssueToDeal("a"))
+	require.Equal(t, IssueID("d"), issues.GetNextIssueToDeal("c"))
+
+	// Deferred issues are dealt when there are no other issues left
+	issues[3].Result = &result
+	require.Equal(t, IssueID("b"), issues.GetNextIssueToDeal("d"))
+
+	issues[1].Result = &result
+	require.Equal(t, IssueID(""), issues.GetNextIssueToDeal("b"))
+
+	// Unknown issue
+	issues[0].Result = nil
+	require.Equal(t, IssueID("a"), issues.GetNextIssueToDeal("unknown"))
+}
@@ -0,0 +1,84 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HintMode defines how the hint is calculated from revealed votes
+type HintMode string
+
+const (
+	// HintModeIndex works on deck indexes only, actual card values are ignored
+	HintModeIndex HintMode = "index"
+	// HintModeNumeric parses card values and calculates statistics in real units
+	HintModeNumeric HintMode = "numeric"
+)
+
+// HintStrategyName selects the algorithm of the hint calculation
+type HintStrategyName string
+
+const (
+	// HintStrategyMedianDeviation recommends the median vote and rejects the votes
+	// when they deviate from the median too much
+	HintStrategyMedianDeviation HintStrategyName = "median-deviation"
+	// HintStrategyStrictConsensus only accepts the votes when all players voted the same
+	HintStrategyStrictConsensus HintStrategyName = "strict-consensus"
+	// HintStrategyMajority recommends the most popular vote and accepts it
+	// when its share is higher than HintThresholds.Majority
+	HintStrategyMajority HintStrategyName = "majority"
+	// HintStrategyMeanRoundedUp recommends the mean vote, rounded up to the next card
+	HintStrategyMeanRoundedUp HintStrategyName = "mean-rounded-up"
+)
+
+// HintThresholds define when the votes are considered acceptable.
+// Deviations are measured in cards count.
+type HintThresholds struct {
+	// MaxDeviation is the maximum acceptable deviation of a vote from the recommended value
+	MaxDeviation float64 `json:"maxDeviation"`
+	// MeanDeviation is the mean deviation from the recommended value,
+	// starting from which the votes are not acceptable
+	MeanDeviation float64 `json:"meanDeviation"`
+	// Majority is the share of votes, which the most popular vote must exceed. Must be in [0, 1).
+	Majority float64 `json:"majority"`
+}
+
+func (t HintThresholds) Validate() error {
+	if t.MaxDeviation < 0 || t.MeanDeviation < 0 {
+		return errors.New("deviation thresholds can't be negative")
+	}
+	if t.Majority < 0 || t.Majority >= 1 {
+		return errors.New("majority threshold must be in [0, 1)")
+	}
+	return nil
+}
+
+// RoomSettings are set by the dealer and shared with players as part of the state
+type RoomSettings struct {
+	// CommitReveal enables commit-reveal voting. Players publish a hash commitment of their
+	// vote during voting and open it after reveal, so that even the dealer can't peek.
+	CommitReveal bool `json:"commitReveal,omitempty"`
+	// HintMode is HintModeIndex when empty, for backward compatibility
+	HintMode HintMode `json:"hintMode,omitempty"`
+	// HintStrategy is HintStrategyMedianDeviation when empty
+	HintStrategy HintStrategyName `json:"hintStrategy,omitempty"`
+	// HintThresholds are the default ones when nil
+	HintThresholds *HintThresholds `json:"hintThresholds,omitempty"`
+	// AutoReveal makes the dealer reveal the votes once all online players, except spectators, voted
+	AutoReveal bool `json:"autoReveal,omitempty"`
+	// AutoRevealDelayMilliseconds is a grace period for players to change their minds before the auto reveal
+	AutoRevealDelayMilliseconds int64 `json:"autoRevealDelay,omitempty"`
+	// VotingTimerMilliseconds is the default voting timebox for dealt issues, zero for no timebox
+	VotingTimerMilliseconds int64 `json:"votingTimer,omitempty"`
+	// JoinApproval makes new players wait in State.PendingPlayers until the dealer approves them
+	JoinApproval bool `json:"joinApproval,omitempty"`
+}
+
+func (s RoomSettings) AutoRevealDelay() time.Duration {
+	return time.Duration(s.AutoRevealDelayMilliseconds) * time.Millisecond
+}
+
+func (s RoomSettings) VotingTimer() time.Duration {
+	return time.Duration(s.VotingTimerMilliseconds) * time.Millisecond
+}
@@ -0,0 +1,117 @@
+package protocol
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// SignedMessage carries a message together with a detached signature of its payload.
+// Signature is made over the raw payload bytes, so that it's verified exactly as received,
+// without marshalling the message again.
+type SignedMessage struct {
+	// Type is the type of the payload message, so that the message can be dispatched before it's verified
+	Type      MessageType     `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Signature []byte          `json:"signature"`
+}
+
+var (
+	ErrMissingSignature = errors.New("missing signature")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrUnexpectedSigner = errors.New("message signed by unexpected key")
+)
+
+// NewSignedMessage marshals the message and wraps it into a SignedMessage, signed with given key.
+// States are signed with the dealer key, player messages with the player key.
+func NewSignedMessage(message any, key *ecdsa.PrivateKey) (*SignedMessage, error) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal message")
+	}
+
+	header, err := UnmarshalMessage(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal message type")
+	}
+
+	signature, err := signBytes(payload, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedMessage{
+		Type:      header.Type,
+		Payload:   payload,
+		Signature: signature,
+	}, nil
+}
+
+// UnmarshalSignedMessage unmarshals the payload of a signed message into given message.
+// The signature is not verified, as the key depends on the message content, e.g. the player ID.
+// Messages published without the signature are unmarshalled as is and fail the verification.
+func UnmarshalSignedMessage(data []byte, message any) (*SignedMessage, error) {
+	signed := SignedMessage{}
+	err := json.Unmarshal(data, &signed)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signed.Payload) == 0 {
+		signed.Signature = nil
+		return &signed, json.Unmarshal(data, message)
+	}
+
+	return &signed, json.Unmarshal(signed.Payload, message)
+}
+
+// VerifySignature checks that the payload is signed with given public key
+func (m *SignedMessage) VerifySignature(key PublicKey) error {
+	return verifyBytes(m.Payload, m.Signature, key)
+}
+
+// SignDealerKey signs the dealer key with the player key of the dealer.
+// It proves that the dealer key belongs to the player, who publishes states as the dealer.
+func SignDealerKey(dealerKey PublicKey, playerKey *ecdsa.PrivateKey) ([]byte, error) {
+	return signBytes(dealerKey, playerKey)
+}
+
+// VerifyDealerKey checks that the dealer key is signed with the key, which the dealer ID is derived from
+func VerifyDealerKey(dealer PlayerID, dealerKey PublicKey, signature []byte) error {
+	playerKey, err := dealer.PublicKey()
+	if err != nil {
+		return err
+	}
+	return verifyBytes(dealerKey, signature, playerKey)
+}
+
+func signBytes(data []byte, key *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(crypto.Keccak256(data), key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign message")
+	}
+	return signature, nil
+}
+
+func verifyBytes(data []byte, signature []byte, key PublicKey) error {
+	if len(signature) == 0 {
+		return ErrMissingSignature
+	}
+	if key.Empty() {
+		return errors.New("no public key to verify signature")
+	}
+
+	signer, err := crypto.SigToPub(crypto.Keccak256(data), signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !bytes.Equal(NewPublicKey(signer), key) {
+		return ErrUnexpectedSigner
+	}
+
+	return nil
+}
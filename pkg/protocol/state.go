@@ -3,12 +3,27 @@ package protocol
 import "github.com/six78/2-story-points-cli/internal/config"
 
 type State struct {
-	Players       PlayersList `json:"players"`
-	Issues        IssuesList  `json:"issues"`
-	ActiveIssue   IssueID     `json:"activeIssue"`
-	VotesRevealed bool        `json:"votesRevealed"`
-	Timestamp     int64       `json:"-"` // TODO: Fix conflict with Message.Timestamp. Change type to time.Time.
-	Deck          Deck        `json:"-"`
+	Players       PlayersList  `json:"players"`
+	Issues        IssuesList   `json:"issues"`
+	ActiveIssue   IssueID      `json:"activeIssue"`
+	VotesRevealed bool         `json:"votesRevealed"`
+	Dealer        PlayerID     `json:"dealer"`
+	DealerTerm    int          `json:"dealerTerm"`
+	DealerKey     PublicKey    `json:"dealerKey,omitempty"`
+	Settings      RoomSettings `json:"settings"`
+	Timestamp     int64        `json:"-"` // TODO: Fix conflict with Message.Timestamp. Change type to time.Time.
+	Deck          Deck         `json:"deck"`
+
+	// AutoRevealAt is the unix time in milliseconds, when the dealer is going to reveal the votes.
+	// It's set when RoomSettings.AutoReveal is enabled and everyone voted, zero otherwise.
+	AutoRevealAt int64 `json:"autoRevealAt,omitempty"`
+
+	// PendingPlayers are waiting for the dealer approval to join the room.
+	// Only used when RoomSettings.JoinApproval is enabled.
+	PendingPlayers PlayersList `json:"pendingPlayers,omitempty"`
+
+	// BannedPlayers are ignored by the dealer and can't rejoin the room
+	BannedPlayers []PlayerID `json:"bannedPlayers,omitempty"`
 }
 
 type VoteState string
@@ -38,6 +53,47 @@ func (s *State) VoteState() VoteState {
 	return FinishedState
 }
 
+// DealerSuccessors returns online players, except the current dealer, in the order
+// they should take over the dealer role if the dealer disappears.
+// The order is deterministic, so that every player computes the same list.
+func (s *State) DealerSuccessors() []PlayerID {
+	successors := make([]PlayerID, 0, len(s.Players))
+	for _, player := range s.Players {
+		if player.ID == s.Dealer || !player.Online {
+			continue
+		}
+		successors = append(successors, player.ID)
+	}
+	return successors
+}
+
+// DealerSupersedes reports whether the dealer of this state takes precedence over
+// the dealer of the other state. Each handover or failover increments DealerTerm,
+// so a dealer that comes back after being replaced loses to its successor.
+func (s *State) DealerSupersedes(other *State) bool {
+	if s.DealerTerm != other.DealerTerm {
+		return s.DealerTerm > other.DealerTerm
+	}
+	return s.Dealer <= other.Dealer
+}
+
+// EveryoneVoted reports whether all online players, except spectators, voted for the active issue
+func (s *State) EveryoneVoted() bool {
+	issue := s.GetActiveIssue()
+	if issue == nil {
+		return false
+	}
+	for _, player := range s.Players {
+		if !player.Online || player.Spectator {
+			continue
+		}
+		if _, ok := issue.Votes[player.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *State) GetActiveIssue() *Issue {
 	return s.Issues.Get(s.ActiveIssue)
 }
@@ -7,6 +7,11 @@ type VoteValue string
 type VoteResult struct {
 	Value     VoteValue `json:"estimation"` // TODO:  Vote -> Estimate ?
 	Timestamp int64     `json:"timestamp"`
+
+	// Commitment is set in commit-reveal mode. Value is empty until the vote is opened.
+	Commitment []byte `json:"commitment,omitempty"`
+	// CommitmentMismatch is set by the dealer when the opened vote doesn't match the commitment
+	CommitmentMismatch bool `json:"commitmentMismatch,omitempty"`
 }
 
 func NewVoteResult(value VoteValue) *VoteResult {
@@ -18,7 +23,13 @@ func NewVoteResult(value VoteValue) *VoteResult {
 
 func (v *VoteResult) Hidden() VoteResult {
 	return VoteResult{
-		Value:     "",
-		Timestamp: v.Timestamp,
+		Value:      "",
+		Timestamp:  v.Timestamp,
+		Commitment: v.Commitment,
 	}
 }
+
+// Counted returns true if the vote should be taken into account when calculating the result
+func (v *VoteResult) Counted() bool {
+	return v.Value != "" && !v.CommitmentMismatch
+}
@@ -0,0 +1,254 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/six78/2-story-points-cli/pkg/game"
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+// Format of the exported report
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatCSV      Format = "csv"
+	FormatJSON     Format = "json"
+)
+
+// ParseFormat accepts format names and common file extensions, e.g. "md"
+func ParseFormat(input string) (Format, error) {
+	switch strings.ToLower(input) {
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "csv":
+		return FormatCSV, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("unknown report format: '%s', expected markdown, csv or json", input)
+}
+
+// Extension returns the file extension for the format, without the dot
+func (f Format) Extension() string {
+	switch f {
+	case FormatMarkdown:
+		return "md"
+	default:
+		return string(f)
+	}
+}
+
+// Report is the summary of a refinement session
+type Report struct {
+	Deck   protocol.Deck `json:"deck"`
+	Issues []IssueReport `json:"issues"`
+}
+
+type IssueReport struct {
+	TitleOrURL  string              `json:"titleOrUrl"`
+	ExternalKey string              `json:"externalKey,omitempty"`
+	Result      *protocol.VoteValue `json:"result"`
+	Votes       []VoteCount         `json:"votes"`
+	Hint        *HintReport         `json:"hint,omitempty"`
+	Rounds      int                 `json:"rounds"`
+}
+
+// VoteCount is the number of players who voted for the value
+type VoteCount struct {
+	Value protocol.VoteValue `json:"value"`
+	Count int                `json:"count"`
+}
+
+type HintReport struct {
+	Value        protocol.VoteValue `json:"value"`
+	Acceptable   bool               `json:"acceptable"`
+	RejectReason string             `json:"rejectReason,omitempty"`
+}
+
+// Build creates a report from the dealer state.
+// Hidden votes, e.g. of the issue being voted, are not counted.
+func Build(state *protocol.State) (*Report, error) {
+	if state == nil {
+		return nil, errors.New("no state to export")
+	}
+
+	report := &Report{
+		Deck:   state.Deck,
+		Issues: make([]IssueReport, 0, len(state.Issues)),
+	}
+
+	for _, issue := range state.Issues {
+		votes := game.CountedVotes(state, issue)
+		item := IssueReport{
+			TitleOrURL:  issue.TitleOrURL,
+			ExternalKey: issue.ExternalKey,
+			Result:      issue.Result,
+			Votes:       VoteDistribution(state.Deck, votes),
+			Rounds:      issue.RoundsCount(),
+		}
+
+		if len(votes) > 0 {
+			hint, err := game.GetRoomHint(state.Settings, state.Deck, votes)
+			if err != nil && !errors.Is(err, game.ErrNoNumericVotes) {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to calculate hint for '%s'", issue.TitleOrURL))
+			}
+			if hint != nil {
+				item.Hint = &HintReport{
+					Value:        hint.Value,
+					Acceptable:   hint.Acceptable,
+					RejectReason: hint.RejectReason,
+				}
+			}
+		}
+
+		report.Issues = append(report.Issues, item)
+	}
+
+	return report, nil
+}
+
+// VoteDistribution counts the votes in the deck order.
+// Values, which are not in the deck, go last.
+func VoteDistribution(deck protocol.Deck, votes protocol.IssueVotes) []VoteCount {
+	counts := make(map[protocol.VoteValue]int, len(votes))
+	for _, vote := range votes {
+		counts[vote.Value]++
+	}
+
+	distribution := make([]VoteCount, 0, len(counts))
+	for _, value := range deck {
+		if count, ok := counts[value]; ok {
+			distribution = append(distribution, VoteCount{Value: value, Count: count})
+			delete(counts, value)
+		}
+	}
+
+	var unknown []string
+	for value := range counts {
+		unknown = append(unknown, string(value))
+	}
+	// Keep the output stable
+	sort.Strings(unknown)
+	for _, value := range unknown {
+		distribution = append(distribution, VoteCount{Value: protocol.VoteValue(value), Count: counts[protocol.VoteValue(value)]})
+	}
+
+	return distribution
+}
+
+// Write renders the report in given format
+func Write(w io.Writer, report *Report, format Format) error {
+	switch format {
+	case FormatMarkdown:
+		return writeMarkdown(w, report)
+	case FormatCSV:
+		return writeCSV(w, report)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return fmt.Errorf("unknown report format: '%s'", format)
+}
+
+func writeMarkdown(w io.Writer, report *Report) error {
+	lines := []string{
+		"# Refinement report",
+		"",
+		"| # | Issue | Result | Votes | Hint | Rounds |",
+		"|---|-------|--------|-------|------|--------|",
+	}
+
+	for i, issue := range report.Issues {
+		title := issue.TitleOrURL
+		if issue.ExternalKey != "" {
+			title = issue.ExternalKey + " " + title
+		}
+		row := []string{
+			strconv.Itoa(i + 1),
+			title,
+			resultString(issue.Result),
+			votesString(issue.Votes),
+			hintString(issue.Hint),
+			strconv.Itoa(issue.Rounds),
+		}
+		for j := range row {
+			row[j] = strings.ReplaceAll(row[j], "|", "\\|")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func writeCSV(w io.Writer, report *Report) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"issue", "key", "result", "votes", "hint", "acceptable", "rounds"})
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range report.Issues {
+		hint := ""
+		acceptable := ""
+		if issue.Hint != nil {
+			hint = string(issue.Hint.Value)
+			acceptable = strconv.FormatBool(issue.Hint.Acceptable)
+		}
+		err = writer.Write([]string{
+			issue.TitleOrURL,
+			issue.ExternalKey,
+			resultString(issue.Result),
+			votesString(issue.Votes),
+			hint,
+			acceptable,
+			strconv.Itoa(issue.Rounds),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func resultString(result *protocol.VoteValue) string {
+	if result == nil {
+		return ""
+	}
+	return string(*result)
+}
+
+// votesString renders the distribution as "3×2 5×1"
+func votesString(votes []VoteCount) string {
+	items := make([]string, 0, len(votes))
+	for _, vote := range votes {
+		items = append(items, fmt.Sprintf("%s×%d", vote.Value, vote.Count))
+	}
+	return strings.Join(items, " ")
+}
+
+func hintString(hint *HintReport) string {
+	if hint == nil {
+		return ""
+	}
+	if hint.Acceptable {
+		return string(hint.Value) + " ✓"
+	}
+	if hint.RejectReason == "" {
+		return string(hint.Value) + " x"
+	}
+	return fmt.Sprintf("%s x (%s)", hint.Value, hint.RejectReason)
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/six78/2-story-points-cli/pkg/protocol"
+)
+
+func testState() *protocol.State {
+	result := protocol.VoteValue("3")
+	return &protocol.State{
+		Deck: protocol.Deck{"1", "2", "3", "5", "8", "?"},
+		Issues: protocol.IssuesList{
+			{
+				ID:         "1",
+				TitleOrURL: "https://github.com/six78/2-story-points-cli/issues/1",
+				Votes: protocol.IssueVotes{
+					"a": {Value: "3"},
+					"b": {Value: "3"},
+					"c": {Value: "2"},
+					"d": {Value: "?"},
+				},
+				Result: &result,
+				Rounds: []protocol.VotingRound{{}},
+				IssueMetadata: protocol.IssueMetadata{
+					ExternalKey: "PROJ-1",
+				},
+			},
+			{
+				ID:         "2",
+				TitleOrURL: "Title | with pipe",
+				Votes:      protocol.IssueVotes{},
+			},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, expected := range map[string]Format{
+		"md":       FormatMarkdown,
+		"Markdown": FormatMarkdown,
+		"csv":      FormatCSV,
+		"json":     FormatJSON,
+	} {
+		format, err := ParseFormat(input)
+		require.NoError(t, err)
+		require.Equal(t, expected, format)
+	}
+
+	_, err := ParseFormat("xml")
+	require.Error(t, err)
+
+	require.Equal(t, "md", FormatMarkdown.Extension())
+	require.Equal(t, "csv", FormatCSV.Extension())
+}
+
+func TestBuild(t *testing.T) {
+	_, err := Build(nil)
+	require.Error(t, err)
+
+	report, err := Build(testState())
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 2)
+
+	issue := report.Issues[0]
+	require.Equal(t, "PROJ-1", issue.ExternalKey)
+	require.Equal(t, protocol.VoteValue("3"), *issue.Result)
+	require.Equal(t, 2, issue.Rounds)
+	require.Equal(t, []VoteCount{
+		{Value: "2", Count: 1},
+		{Value: "3", Count: 2},
+		{Value: "?", Count: 1},
+	}, issue.Votes)
+	require.NotNil(t, issue.Hint)
+	require.Equal(t, protocol.VoteValue("3"), issue.Hint.Value)
+	require.True(t, issue.Hint.Acceptable)
+
+	// Issue without votes
+	issue = report.Issues[1]
+	require.Nil(t, issue.Result)
+	require.Empty(t, issue.Votes)
+	require.Nil(t, issue.Hint)
+	require.Equal(t, 1, issue.Rounds)
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	report, err := Build(testState())
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	err = Write(&buffer, report, FormatMarkdown)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Equal(t, []string{
+		"# Refinement report",
+		"",
+		"| # | Issue | Result | Votes | Hint | Rounds |",
+		"|---|-------|--------|-------|------|--------|",
+		"| 1 | PROJ-1 https://github.com/six78/2-story-points-cli/issues/1 | 3 | 2×1 3×2 ?×1 | 3 ✓ | 2 |",
+		"| 2 | Title \\| with pipe |  |  |  | 1 |",
+	}, lines)
+}
+
+func TestWriteCSV(t *testing.T) {
+	report, err := Build(testState())
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	err = Write(&buffer, report, FormatCSV)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"issue", "key", "result", "votes", "hint", "acceptable", "rounds"},
+		{"https://github.com/six78/2-story-points-cli/issues/1", "PROJ-1", "3", "2×1 3×2 ?×1", "3", "true", "2"},
+		{"Title | with pipe", "", "", "", "", "", "1"},
+	}, records)
+}
+
+func TestWriteJSON(t *testing.T) {
+	report, err := Build(testState())
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	err = Write(&buffer, report, FormatJSON)
+	require.NoError(t, err)
+
+	var received Report
+	err = json.Unmarshal(buffer.Bytes(), &received)
+	require.NoError(t, err)
+	require.Equal(t, *report, received)
+}
@@ -1,12 +1,17 @@
 package storage
 
 import (
+	"crypto/ecdsa"
 	"encoding/json"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 	"github.com/six78/2-story-points-cli/internal/config"
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 	"go.uber.org/zap"
+	"os"
 	"path"
+	"path/filepath"
 	"sync"
 
 	"github.com/shibukawa/configdir"
@@ -14,6 +19,7 @@ import (
 
 const (
 	playerStorageFileName = "player.json"
+	decksStorageFileName  = "decks.json"
 	roomsDirectory        = "rooms"
 )
 
@@ -31,11 +37,12 @@ type LocalStorage struct {
 type playerStorage struct {
 	ID   protocol.PlayerID `json:"id"`
 	Name string            `json:"name"`
+	Key  hexutil.Bytes     `json:"key,omitempty"`
 }
 
 type roomStorage struct {
-	// TODO: PrivateKey string
-	State *protocol.State `json:"state"`
+	DealerKey hexutil.Bytes   `json:"dealerKey,omitempty"`
+	State     *protocol.State `json:"state"`
 }
 
 func NewLocalStorage(localPath string) *LocalStorage {
@@ -65,7 +72,8 @@ func (s *LocalStorage) Initialize() error {
 	}
 
 	config.Logger.Info("storage initialized",
-		zap.Any("player", s.player),
+		zap.Any("playerID", s.player.ID), // don't log the private key
+		zap.String("playerName", s.player.Name),
 		zap.String("path", s.folder.Path),
 		zap.Error(err),
 	)
@@ -114,6 +122,7 @@ func (s *LocalStorage) ResetPlayer() error {
 	defer s.mutex.Unlock()
 	s.player.ID = ""
 	s.player.Name = ""
+	s.player.Key = nil
 	return s.savePlayerStorage()
 }
 
@@ -143,7 +152,97 @@ func (s *LocalStorage) SetPlayerName(name string) error {
 	return s.savePlayerStorage()
 }
 
+func (s *LocalStorage) LoadPlayerKey() (*ecdsa.PrivateKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if len(s.player.Key) == 0 {
+		return nil, errors.New("no player key in storage")
+	}
+
+	return crypto.ToECDSA(s.player.Key)
+}
+
+func (s *LocalStorage) SavePlayerKey(key *ecdsa.PrivateKey) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.player.Key = crypto.FromECDSA(key)
+	return s.savePlayerStorage()
+}
+
 func (s *LocalStorage) LoadRoomState(roomID protocol.RoomID) (*protocol.State, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	room, err := s.readRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	return room.State, nil
+}
+
+func (s *LocalStorage) SaveRoomState(roomID protocol.RoomID, state *protocol.State) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	room, err := s.readRoom(roomID)
+	if err != nil {
+		room = &roomStorage{}
+	}
+
+	room.State = state
+	return s.writeRoom(roomID, room)
+}
+
+func (s *LocalStorage) LoadRoomDealerKey(roomID protocol.RoomID) (*ecdsa.PrivateKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	room, err := s.readRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(room.DealerKey) == 0 {
+		return nil, errors.New("no dealer key in room storage")
+	}
+
+	return crypto.ToECDSA(room.DealerKey)
+}
+
+func (s *LocalStorage) SaveRoomDealerKey(roomID protocol.RoomID, key *ecdsa.PrivateKey) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	room, err := s.readRoom(roomID)
+	if err != nil {
+		room = &roomStorage{}
+	}
+
+	room.DealerKey = crypto.FromECDSA(key)
+	return s.writeRoom(roomID, room)
+}
+
+// DeleteRoom removes the state and the dealer key of the room
+func (s *LocalStorage) DeleteRoom(roomID protocol.RoomID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	filePath := roomFilePath(roomID)
+	if !s.folder.Exists(filePath) {
+		return nil
+	}
+
+	err := os.Remove(filepath.Join(s.folder.Path, filePath))
+	if err != nil {
+		return errors.Wrap(err, "failed to delete room storage")
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) readRoom(roomID protocol.RoomID) (*roomStorage, error) {
 	filePath := roomFilePath(roomID)
 
 	data, err := s.folder.ReadFile(filePath)
@@ -157,14 +256,10 @@ func (s *LocalStorage) LoadRoomState(roomID protocol.RoomID) (*protocol.State, e
 		return nil, errors.Wrap(err, "failed to unmarshal storage file")
 	}
 
-	return room.State, nil
+	return &room, nil
 }
 
-func (s *LocalStorage) SaveRoomState(roomID protocol.RoomID, state *protocol.State) error {
-	room := roomStorage{
-		State: state,
-	}
-
+func (s *LocalStorage) writeRoom(roomID protocol.RoomID, room *roomStorage) error {
 	roomJson, err := json.Marshal(room)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal room data")
@@ -180,6 +275,79 @@ func (s *LocalStorage) SaveRoomState(roomID protocol.RoomID, state *protocol.Sta
 	return nil
 }
 
+// decksStorage contains user-defined decks by name
+type decksStorage map[string]protocol.Deck
+
+func (s *LocalStorage) LoadDecks() (map[string]protocol.Deck, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.readDecks()
+}
+
+func (s *LocalStorage) SaveDeck(name string, deck protocol.Deck) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	decks, err := s.readDecks()
+	if err != nil {
+		return err
+	}
+
+	decks[name] = deck
+	return s.writeDecks(decks)
+}
+
+func (s *LocalStorage) DeleteDeck(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	decks, err := s.readDecks()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := decks[name]; !ok {
+		return errors.New("deck not found")
+	}
+
+	delete(decks, name)
+	return s.writeDecks(decks)
+}
+
+func (s *LocalStorage) readDecks() (decksStorage, error) {
+	decks := decksStorage{}
+
+	if !s.folder.Exists(decksStorageFileName) {
+		return decks, nil
+	}
+
+	data, err := s.folder.ReadFile(decksStorageFileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read decks storage file")
+	}
+
+	err = json.Unmarshal(data, &decks)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal decks storage file")
+	}
+
+	return decks, nil
+}
+
+func (s *LocalStorage) writeDecks(decks decksStorage) error {
+	decksJson, err := json.Marshal(decks)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal decks")
+	}
+
+	err = s.folder.WriteFile(decksStorageFileName, decksJson)
+	if err != nil {
+		return errors.Wrap(err, "failed to write decks storage")
+	}
+
+	return nil
+}
+
 func roomFilePath(roomID protocol.RoomID) string {
 	return path.Join(roomsDirectory, roomID.String()+".json")
 }
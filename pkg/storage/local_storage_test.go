@@ -101,7 +101,6 @@ func (s *Suite) TestRoomStorage() {
 	loadedState, err := s.storage.LoadRoomState(roomID)
 	resetPlayersTimestamps(state)
 	resetPlayersTimestamps(loadedState)
-	loadedState.Deck = state.Deck
 	loadedState.Timestamp = state.Timestamp
 	for _, issue := range state.Issues {
 		issue.Hint = nil
@@ -155,3 +154,97 @@ func (s *Suite) TestResetPlayerOnUnmarshalFailure() {
 	s.Require().Empty(newStorage.PlayerID())
 	s.Require().Empty(newStorage.PlayerName())
 }
+
+func (s *Suite) TestRoomDealerKeyStorage() {
+	roomID := protocol.NewRoomID(gofakeit.LetterN(5))
+	_, err := s.storage.LoadRoomDealerKey(roomID)
+	s.Require().Error(err)
+
+	key, err := protocol.GeneratePrivateKey()
+	s.Require().NoError(err)
+
+	err = s.storage.SaveRoomDealerKey(roomID, key)
+	s.Require().NoError(err)
+
+	// Saving state should keep the key
+	err = s.storage.SaveRoomState(roomID, &protocol.State{})
+	s.Require().NoError(err)
+
+	loadedKey, err := s.storage.LoadRoomDealerKey(roomID)
+	s.Require().NoError(err)
+	s.Require().True(key.Equal(loadedKey))
+
+	state, err := s.storage.LoadRoomState(roomID)
+	s.Require().NoError(err)
+	s.Require().NotNil(state)
+
+	// Deleting the room removes both the state and the key
+	err = s.storage.DeleteRoom(roomID)
+	s.Require().NoError(err)
+	_, err = s.storage.LoadRoomState(roomID)
+	s.Require().Error(err)
+	_, err = s.storage.LoadRoomDealerKey(roomID)
+	s.Require().Error(err)
+
+	// Deleting a missing room is not an error
+	err = s.storage.DeleteRoom(roomID)
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestPlayerKeyStorage() {
+	_, err := s.storage.LoadPlayerKey()
+	s.Require().Error(err)
+
+	key, err := protocol.GeneratePrivateKey()
+	s.Require().NoError(err)
+
+	err = s.storage.SavePlayerKey(key)
+	s.Require().NoError(err)
+
+	// Key is persisted with other player data
+	storage := NewLocalStorage(s.tempPath)
+	err = storage.Initialize()
+	s.Require().NoError(err)
+
+	loadedKey, err := storage.LoadPlayerKey()
+	s.Require().NoError(err)
+	s.Require().True(key.Equal(loadedKey))
+}
+
+func (s *Suite) TestDecksStorage() {
+	decks, err := s.storage.LoadDecks()
+	s.Require().NoError(err)
+	s.Require().Empty(decks)
+
+	deck := protocol.Deck{"S", "M", "L"}
+	err = s.storage.SaveDeck("sizes", deck)
+	s.Require().NoError(err)
+
+	anotherDeck := protocol.Deck{"1", "10", "100"}
+	err = s.storage.SaveDeck("log", anotherDeck)
+	s.Require().NoError(err)
+
+	// Decks are persisted
+	storage := NewLocalStorage(s.tempPath)
+	err = storage.Initialize()
+	s.Require().NoError(err)
+
+	decks, err = storage.LoadDecks()
+	s.Require().NoError(err)
+	s.Require().Equal(map[string]protocol.Deck{
+		"sizes": deck,
+		"log":   anotherDeck,
+	}, decks)
+
+	err = storage.DeleteDeck("sizes")
+	s.Require().NoError(err)
+
+	err = storage.DeleteDeck("sizes")
+	s.Require().Error(err)
+
+	decks, err = s.storage.LoadDecks()
+	s.Require().NoError(err)
+	s.Require().Equal(map[string]protocol.Deck{
+		"log": anotherDeck,
+	}, decks)
+}
@@ -3,6 +3,8 @@ package storage
 //go:generate mockgen -source=service.go -destination=mock/service.go
 
 import (
+	"crypto/ecdsa"
+
 	"github.com/six78/2-story-points-cli/pkg/protocol"
 )
 
@@ -12,6 +14,14 @@ type Service interface {
 	PlayerName() string
 	SetPlayerID(id protocol.PlayerID) error
 	SetPlayerName(name string) error
+	LoadPlayerKey() (*ecdsa.PrivateKey, error)
+	SavePlayerKey(key *ecdsa.PrivateKey) error
 	LoadRoomState(roomID protocol.RoomID) (*protocol.State, error)
 	SaveRoomState(roomID protocol.RoomID, state *protocol.State) error
+	LoadRoomDealerKey(roomID protocol.RoomID) (*ecdsa.PrivateKey, error)
+	SaveRoomDealerKey(roomID protocol.RoomID, key *ecdsa.PrivateKey) error
+	DeleteRoom(roomID protocol.RoomID) error
+	LoadDecks() (map[string]protocol.Deck, error)
+	SaveDeck(name string, deck protocol.Deck) error
+	DeleteDeck(name string) error
 }